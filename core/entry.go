@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,8 +17,11 @@ type LogEntry struct {
 	Level         Level                `json:"level"`                  // Log severity level
 	LevelName     []byte               `json:"level_name"`             // Byte representation of level for zero-allocation formatting
 	Message       []byte               `json:"message"`                // Log message
+	LoggerName    []byte               `json:"logger,omitempty"`       // Dotted name of the logger that created the entry
 	Caller        *CallerInfo          `json:"caller,omitempty"`       // Caller information
+	PC            uintptr              `json:"-"`                      // Program counter of the call site, set for samplers that key entries by caller
 	Fields        map[string][]byte      `json:"fields,omitempty"`     // Additional fields as []byte for zero allocation
+	TypedFields   []Field              `json:"typed_fields,omitempty"` // Additional fields that keep their native value type
 	PID           int                  `json:"pid"`                    // Process ID
 	GoroutineID   []byte               `json:"goroutine_id,omitempty"` // Goroutine ID as byte slice
 	TraceID       []byte               `json:"trace_id,omitempty"`     // Trace ID for distributed tracing as byte slice
@@ -217,6 +221,7 @@ var entryPool = sync.Pool{
 		}
 		return &LogEntry{
 			Fields:        GetMapByteFromPool(),
+			TypedFields:   make([]Field, 0, FieldsMapCapacity),
 			CustomMetrics: GetMapFloatFromPool(),
 			Tags:          tagsAsBytes,
 		}
@@ -250,9 +255,9 @@ const (
 // GetEntryFromPool gets a LogEntry from the pool
 // Mendapatkan LogEntry dari pool
 func GetEntryFromPool() *LogEntry {
-	// Fallback: Use regular goroutine-local pool
-	localPool := GetGoroutineLocalEntryPool()
-	return localPool.GetEntryFromLocalPool()
+	// The goroutine-local pool is keyed by a time-based pseudo ID and creates a new
+	// pool on almost every call, so the hot path uses the global pool directly
+	return GetEntryFromGlobalPool()
 }
 
 // GetEntryFromGlobalPool gets a LogEntry directly from the global pool
@@ -275,8 +280,11 @@ func GetEntryFromGlobalPool() *LogEntry {
 	entry.Level = INFO
 	entry.LevelName = nil
 	entry.Message = nil
+	entry.LoggerName = nil
 	entry.Caller = nil
+	entry.PC = 0
 	clearMap(entry.Fields)
+	entry.TypedFields = clearFields(entry.TypedFields)
 	clearFloatMap(entry.CustomMetrics)
 	entry.Tags = clearByteSliceSlice(entry.Tags)
 	entry.PID = 0
@@ -337,8 +345,10 @@ func (g *GoroutineLocalEntryPool) GetEntryFromLocalPool() *LogEntry {
 		entry.Level = INFO
 		entry.LevelName = nil
 		entry.Message = nil
+		entry.LoggerName = nil
 		entry.Caller = nil
 		clearMap(entry.Fields)
+		entry.TypedFields = clearFields(entry.TypedFields)
 		clearFloatMap(entry.CustomMetrics)
 		entry.Tags = clearByteSliceSlice(entry.Tags)
 		entry.PID = 0
@@ -379,9 +389,61 @@ func PutEntryToPool(entry *LogEntry) {
         PutBufferToPool(entry.StackTraceBufPtr)
         entry.StackTraceBufPtr = nil
     }
-	// Gunakan goroutine-local pool jika tersedia
-	localPool := GetGoroutineLocalEntryPool()
-	localPool.PutEntryToLocalPool(entry)
+	globalEntryMetrics.IncEntrySerialized()
+	entryPool.Put(entry)
+}
+
+// Clone returns a deep copy of the entry that stays valid after the entry is returned
+// to the pool, e.g. to keep it beyond the call that received it. The copy does not
+// come from the pool but can be returned to it.
+func (le *LogEntry) Clone() *LogEntry {
+	c := *le
+	c.LevelName = bytes.Clone(le.LevelName)
+	c.Message = bytes.Clone(le.Message)
+	c.LoggerName = bytes.Clone(le.LoggerName)
+	c.GoroutineID = bytes.Clone(le.GoroutineID)
+	c.TraceID = bytes.Clone(le.TraceID)
+	c.SpanID = bytes.Clone(le.SpanID)
+	c.UserID = bytes.Clone(le.UserID)
+	c.SessionID = bytes.Clone(le.SessionID)
+	c.RequestID = bytes.Clone(le.RequestID)
+	c.StackTrace = bytes.Clone(le.StackTrace)
+	c.StackTraceBufPtr = nil
+	c.Hostname = bytes.Clone(le.Hostname)
+	c.Application = bytes.Clone(le.Application)
+	c.Version = bytes.Clone(le.Version)
+	c.Environment = bytes.Clone(le.Environment)
+
+	if le.Caller != nil {
+		caller := *le.Caller
+		c.Caller = &caller
+	}
+	c.Fields = make(map[string][]byte, len(le.Fields))
+	for k, v := range le.Fields {
+		c.Fields[k] = bytes.Clone(v)
+	}
+	c.TypedFields = nil
+	if le.TypedFields != nil {
+		c.TypedFields = make([]Field, len(le.TypedFields))
+		for i, f := range le.TypedFields {
+			f.Key = strings.Clone(f.Key)
+			f.String = strings.Clone(f.String)
+			f.Bytes = bytes.Clone(f.Bytes)
+			c.TypedFields[i] = f
+		}
+	}
+	c.CustomMetrics = make(map[string]float64, len(le.CustomMetrics))
+	for k, v := range le.CustomMetrics {
+		c.CustomMetrics[k] = v
+	}
+	c.Tags = nil
+	if le.Tags != nil {
+		c.Tags = make([][]byte, len(le.Tags))
+		for i, tag := range le.Tags {
+			c.Tags[i] = bytes.Clone(tag)
+		}
+	}
+	return &c
 }
 
 // ZeroAllocJSONSerialize serializes the LogEntry to JSON without memory allocation
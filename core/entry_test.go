@@ -559,4 +559,38 @@ func TestLogEntryFloatConversion(t *testing.T) {
 		t.Error("floatToBytes returned empty result")
 	}
 	// This is harder to test exactly due to floating point precision, but we can at least verify it's not empty
-}
\ No newline at end of file
+}
+// TestLogEntryClone tests that a clone does not share memory with the pooled entry
+func TestLogEntryClone(t *testing.T) {
+	entry := GetEntryFromPool()
+	entry.Level = DEBUG
+	entry.Message = []byte("original")
+	entry.TraceID = []byte("trace-1")
+	entry.Caller = &CallerInfo{File: "main.go", Line: 7}
+	entry.Fields["user"] = []byte("ana")
+	entry.TypedFields = append(entry.TypedFields, ByteString("raw", []byte("bytes")))
+	entry.Tags = append(entry.Tags, []byte("tag"))
+
+	clone := entry.Clone()
+	entry.Message[0] = 'X'
+	entry.TraceID[0] = 'X'
+	entry.Caller.Line = 8
+	entry.Fields["user"][0] = 'X'
+	entry.TypedFields[0].Bytes[0] = 'X'
+	entry.Tags[0][0] = 'X'
+	PutEntryToPool(entry)
+
+	if string(clone.Message) != "original" || string(clone.TraceID) != "trace-1" || clone.Caller.Line != 7 {
+		t.Errorf("Unexpected clone %q %q %d", clone.Message, clone.TraceID, clone.Caller.Line)
+	}
+	if string(clone.Fields["user"]) != "ana" || string(clone.TypedFields[0].Bytes) != "bytes" || string(clone.Tags[0]) != "tag" {
+		t.Errorf("Expected the fields and tags to be copied, got %q %q %q", clone.Fields["user"], clone.TypedFields[0].Bytes, clone.Tags[0])
+	}
+
+	// A clone can be returned to the pool and reused
+	PutEntryToPool(clone)
+	reused := GetEntryFromPool()
+	reused.Fields["k"] = []byte("v")
+	reused.CustomMetrics["m"] = 1
+	PutEntryToPool(reused)
+}
@@ -0,0 +1,316 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// FieldType identifies how the value of a Field is stored and rendered
+type FieldType uint8
+
+const (
+	// UnknownType is the zero value of FieldType, fields of this type are skipped
+	UnknownType FieldType = iota
+	// StringType holds a string in Field.String
+	StringType
+	// BytesType holds a byte slice in Field.Bytes, rendered as a string
+	BytesType
+	// Int64Type holds a signed integer in Field.Integer
+	Int64Type
+	// Uint64Type holds an unsigned integer in Field.Integer (bit pattern)
+	Uint64Type
+	// Float64Type holds the IEEE 754 bits of a float64 in Field.Integer
+	Float64Type
+	// BoolType holds 1 or 0 in Field.Integer
+	BoolType
+	// DurationType holds nanoseconds in Field.Integer
+	DurationType
+	// TimeType holds Unix nanoseconds in Field.Integer and the *time.Location in Field.Interface
+	TimeType
+	// ErrorType holds an error in Field.Interface
+	ErrorType
+	// AnyType holds an arbitrary value in Field.Interface
+	AnyType
+	// TagsType holds a []string in Field.Interface, loggers add it to LogEntry.Tags
+	TagsType
+	// MetricType holds the IEEE 754 bits of a float64 in Field.Integer, loggers add it to LogEntry.CustomMetrics
+	MetricType
+)
+
+// Keys of fields that loggers store in the dedicated LogEntry slots
+const (
+	ErrorKey    = "error"
+	DurationKey = "duration"
+	TagsKey     = "tags"
+)
+
+// Field is a typed key/value pair that keeps the native type of its value until
+// formatting, so formatters can emit numbers and booleans as such.
+// Scalar values are packed into Integer, which keeps the constructors allocation free.
+type Field struct {
+	Key       string      // Field name
+	Type      FieldType   // How the value is stored
+	Integer   int64       // Packed scalar value for numeric, bool, duration and time fields
+	String    string      // Value for string fields
+	Bytes     []byte      // Value for byte slice fields
+	Interface interface{} // Value for error and any fields, location for time fields
+}
+
+// String creates a string field
+func String(key, value string) Field {
+	return Field{Key: key, Type: StringType, String: value}
+}
+
+// ByteString creates a field from a byte slice that is rendered as a string
+func ByteString(key string, value []byte) Field {
+	return Field{Key: key, Type: BytesType, Bytes: value}
+}
+
+// Int creates a signed integer field
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 creates a signed integer field
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: Int64Type, Integer: value}
+}
+
+// Uint64 creates an unsigned integer field
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: Uint64Type, Integer: int64(value)}
+}
+
+// Float64 creates a floating point field
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: Float64Type, Integer: int64(math.Float64bits(value))}
+}
+
+// Bool creates a boolean field
+func Bool(key string, value bool) Field {
+	var i int64
+	if value {
+		i = 1
+	}
+	return Field{Key: key, Type: BoolType, Integer: i}
+}
+
+// Duration creates a duration field. Structured formatters emit it as nanoseconds.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(value)}
+}
+
+// Time creates a timestamp field
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Type: TimeType, Integer: value.UnixNano(), Interface: value.Location()}
+}
+
+// Err creates an error field with the key "error".
+// Loggers store it in LogEntry.Error rather than in the field list.
+func Err(err error) Field {
+	return NamedErr(ErrorKey, err)
+}
+
+// NamedErr creates an error field with a custom key
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Elapsed creates a duration field with the key "duration".
+// Loggers store it in LogEntry.Duration rather than in the field list.
+func Elapsed(value time.Duration) Field {
+	return Duration(DurationKey, value)
+}
+
+// Tags creates a field whose values loggers append to LogEntry.Tags
+func Tags(tags ...string) Field {
+	return Field{Key: TagsKey, Type: TagsType, Interface: tags}
+}
+
+// Metric creates a field that loggers store in LogEntry.CustomMetrics under the given name
+func Metric(name string, value float64) Field {
+	return Field{Key: name, Type: MetricType, Integer: int64(math.Float64bits(value))}
+}
+
+// Any creates a field from an arbitrary value, choosing the typed
+// representation for the common scalar types
+func Any(key string, value interface{}) Field {
+	switch v := value.(type) {
+	case Field:
+		return v
+	case string:
+		return String(key, v)
+	case []byte:
+		return ByteString(key, v)
+	case int:
+		return Int64(key, int64(v))
+	case int8:
+		return Int64(key, int64(v))
+	case int16:
+		return Int64(key, int64(v))
+	case int32:
+		return Int64(key, int64(v))
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint64(key, uint64(v))
+	case uint8:
+		return Uint64(key, uint64(v))
+	case uint16:
+		return Uint64(key, uint64(v))
+	case uint32:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case float32:
+		return Float64(key, float64(v))
+	case float64:
+		return Float64(key, v)
+	case bool:
+		return Bool(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case error:
+		return NamedErr(key, v)
+	default:
+		return Field{Key: key, Type: AnyType, Interface: value}
+	}
+}
+
+// Float64Value returns the value of a Float64Type field
+func (f Field) Float64Value() float64 {
+	return math.Float64frombits(uint64(f.Integer))
+}
+
+// TimeValue returns the value of a TimeType field
+func (f Field) TimeValue() time.Time {
+	t := time.Unix(0, f.Integer)
+	if loc, ok := f.Interface.(*time.Location); ok && loc != nil {
+		return t.In(loc)
+	}
+	return t
+}
+
+// Value returns the field value boxed in its native Go type
+func (f Field) Value() interface{} {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case BytesType:
+		return f.Bytes
+	case Int64Type:
+		return f.Integer
+	case Uint64Type:
+		return uint64(f.Integer)
+	case Float64Type, MetricType:
+		return f.Float64Value()
+	case BoolType:
+		return f.Integer == 1
+	case DurationType:
+		return time.Duration(f.Integer)
+	case TimeType:
+		return f.TimeValue()
+	default:
+		return f.Interface
+	}
+}
+
+// IsNumeric reports whether the field is rendered as a bare number or boolean
+// by structured formatters
+func (f Field) IsNumeric() bool {
+	switch f.Type {
+	case Int64Type, Uint64Type, BoolType, DurationType:
+		return true
+	case Float64Type, MetricType:
+		v := f.Float64Value()
+		return !math.IsNaN(v) && !math.IsInf(v, 0)
+	}
+	return false
+}
+
+// WriteValue writes the plain text form of the field value to buf.
+// Numbers and booleans are appended into the buffer's spare capacity, so no
+// intermediate strings are created.
+func (f Field) WriteValue(buf *bytes.Buffer) {
+	switch f.Type {
+	case StringType:
+		buf.WriteString(f.String)
+	case BytesType:
+		buf.Write(f.Bytes)
+	case Int64Type:
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), f.Integer, 10))
+	case Uint64Type:
+		buf.Write(strconv.AppendUint(buf.AvailableBuffer(), uint64(f.Integer), 10))
+	case Float64Type, MetricType:
+		buf.Write(strconv.AppendFloat(buf.AvailableBuffer(), f.Float64Value(), 'g', -1, 64))
+	case BoolType:
+		buf.Write(strconv.AppendBool(buf.AvailableBuffer(), f.Integer == 1))
+	case DurationType:
+		buf.WriteString(time.Duration(f.Integer).String())
+	case TimeType:
+		buf.Write(f.TimeValue().AppendFormat(buf.AvailableBuffer(), time.RFC3339Nano))
+	case ErrorType:
+		writeErrorValue(buf, f.Interface)
+	case AnyType:
+		writeAnyValue(buf, f.Interface)
+	case TagsType:
+		tags, _ := f.Interface.([]string)
+		for i, tag := range tags {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(tag)
+		}
+	}
+}
+
+// WriteJSONNumber writes numeric and boolean fields as bare JSON values.
+// It reports false without writing anything for other field types.
+func (f Field) WriteJSONNumber(buf *bytes.Buffer) bool {
+	if !f.IsNumeric() {
+		return false
+	}
+	if f.Type == DurationType {
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), f.Integer, 10))
+		return true
+	}
+	f.WriteValue(buf)
+	return true
+}
+
+// writeErrorValue writes an error message, using ErrorAppender when available
+func writeErrorValue(buf *bytes.Buffer, v interface{}) {
+	err, ok := v.(error)
+	if !ok || err == nil {
+		buf.WriteString("<nil>")
+		return
+	}
+	if appender, ok := err.(ErrorAppender); ok {
+		appender.AppendError(buf)
+		return
+	}
+	buf.WriteString(err.Error())
+}
+
+// writeAnyValue writes an arbitrary value in its %v form
+func writeAnyValue(buf *bytes.Buffer, v interface{}) {
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString("<nil>")
+	case fmt.Stringer:
+		buf.WriteString(val.String())
+	default:
+		fmt.Fprint(buf, val)
+	}
+}
+
+// clearFields resets a field slice, dropping references held by its elements
+func clearFields(s []Field) []Field {
+	clear(s)
+	return s[:0]
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestFieldConstructors tests that each constructor stores the value in its native type
+func TestFieldConstructors(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	err := errors.New("boom")
+
+	tests := []struct {
+		name     string
+		field    Field
+		wantType FieldType
+		want     interface{}
+	}{
+		{"string", String("k", "v"), StringType, "v"},
+		{"int", Int("k", 42), Int64Type, int64(42)},
+		{"int64", Int64("k", -7), Int64Type, int64(-7)},
+		{"uint64", Uint64("k", math.MaxUint64), Uint64Type, uint64(math.MaxUint64)},
+		{"float64", Float64("k", 1.5), Float64Type, 1.5},
+		{"bool", Bool("k", true), BoolType, true},
+		{"duration", Duration("k", 2*time.Second), DurationType, 2 * time.Second},
+		{"error", NamedErr("k", err), ErrorType, err},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.field.Key != "k" {
+				t.Errorf("Expected key 'k', got %q", tt.field.Key)
+			}
+			if tt.field.Type != tt.wantType {
+				t.Errorf("Expected type %d, got %d", tt.wantType, tt.field.Type)
+			}
+			if got := tt.field.Value(); got != tt.want {
+				t.Errorf("Expected value %v (%T), got %v (%T)", tt.want, tt.want, got, got)
+			}
+		})
+	}
+
+	if got := Time("k", now).TimeValue(); !got.Equal(now) || got.Location() != time.UTC {
+		t.Errorf("Expected time %v, got %v", now, got)
+	}
+	if f := Err(err); f.Key != "error" {
+		t.Errorf("Err should use the key 'error', got %q", f.Key)
+	}
+}
+
+// TestAnyField tests that Any picks the typed representation for common types
+func TestAnyField(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		wantType FieldType
+	}{
+		{"text", StringType},
+		{[]byte("raw"), BytesType},
+		{int32(5), Int64Type},
+		{uint8(5), Uint64Type},
+		{float32(2.5), Float64Type},
+		{false, BoolType},
+		{time.Millisecond, DurationType},
+		{time.Now(), TimeType},
+		{errors.New("e"), ErrorType},
+		{struct{ A int }{1}, AnyType},
+	}
+
+	for _, tt := range tests {
+		if f := Any("k", tt.value); f.Type != tt.wantType {
+			t.Errorf("Any(%T) expected type %d, got %d", tt.value, tt.wantType, f.Type)
+		}
+	}
+}
+
+// TestFieldWriteValue tests the plain text rendering of typed fields
+func TestFieldWriteValue(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  string
+	}{
+		{String("k", "hello"), "hello"},
+		{ByteString("k", []byte("bytes")), "bytes"},
+		{Int64("k", -12), "-12"},
+		{Uint64("k", 12), "12"},
+		{Float64("k", 0.25), "0.25"},
+		{Bool("k", true), "true"},
+		{Duration("k", 1500*time.Millisecond), "1.5s"},
+		{Time("k", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)), "2024-01-02T03:04:05Z"},
+		{Err(errors.New("failed")), "failed"},
+		{NamedErr("k", nil), "<nil>"},
+		{Any("k", struct{ A int }{1}), "{1}"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		tt.field.WriteValue(&buf)
+		if buf.String() != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, buf.String())
+		}
+	}
+}
+
+// TestFieldWriteJSONNumber tests that only numeric fields are written as bare JSON values
+func TestFieldWriteJSONNumber(t *testing.T) {
+	var buf bytes.Buffer
+	if !Duration("k", time.Second).WriteJSONNumber(&buf) || buf.String() != "1000000000" {
+		t.Errorf("Duration should be written as nanoseconds, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if !Bool("k", false).WriteJSONNumber(&buf) || buf.String() != "false" {
+		t.Errorf("Bool should be written bare, got %q", buf.String())
+	}
+
+	buf.Reset()
+	if Float64("k", math.NaN()).WriteJSONNumber(&buf) || buf.Len() != 0 {
+		t.Error("NaN is not a valid JSON number and should not be written")
+	}
+
+	buf.Reset()
+	if String("k", "1").WriteJSONNumber(&buf) || buf.Len() != 0 {
+		t.Error("String fields should not be written as numbers")
+	}
+}
+
+// TestEntryTypedFieldsReset tests that typed fields are cleared when an entry returns to the pool
+func TestEntryTypedFieldsReset(t *testing.T) {
+	entry := GetEntryFromPool()
+	entry.TypedFields = append(entry.TypedFields, Int("count", 1))
+	PutEntryToPool(entry)
+
+	entry = GetEntryFromPool()
+	defer PutEntryToPool(entry)
+	if len(entry.TypedFields) != 0 {
+		t.Errorf("Expected empty TypedFields, got %d", len(entry.TypedFields))
+	}
+}
+
+// TestEntrySlotFields tests the constructors for fields that target dedicated entry slots
+func TestEntrySlotFields(t *testing.T) {
+	if f := Elapsed(time.Second); f.Key != DurationKey || f.Type != DurationType {
+		t.Errorf("Elapsed should create a duration field keyed %q, got %+v", DurationKey, f)
+	}
+
+	tags := Tags("db", "slow")
+	if tags.Type != TagsType {
+		t.Errorf("Expected TagsType, got %d", tags.Type)
+	}
+	var buf bytes.Buffer
+	tags.WriteValue(&buf)
+	if buf.String() != "db,slow" {
+		t.Errorf("Expected tags to be joined with commas, got %q", buf.String())
+	}
+
+	m := Metric("rows", 12.5)
+	if m.Key != "rows" || m.Type != MetricType || m.Value() != 12.5 {
+		t.Errorf("Unexpected metric field %+v", m)
+	}
+}
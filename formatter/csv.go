@@ -143,6 +143,8 @@ func (f *CSVFormatter) formatCSVField(buf *bytes.Buffer, field string, entry *co
 		f.writeCSVValueBytes(buf, entry.Level.Bytes())
 	case "message":
 		f.writeCSVValueBytes(buf, entry.Message)
+	case "logger":
+		f.writeCSVValueBytes(buf, entry.LoggerName)
 	case "pid":
 		// Write integer directly to the main buffer to avoid an extra copy
 		buf.WriteByte('"')
@@ -208,6 +210,8 @@ func (f *CSVFormatter) formatCSVField(buf *bytes.Buffer, field string, entry *co
 				util.FormatValue(buf, val, 0)
 				buf.WriteByte('"')
 			}
+		} else if typed := findTypedField(entry.TypedFields, field); typed != nil {
+			f.writeTypedCSVValue(buf, typed)
 		} else {
 			buf.WriteByte('"')
 			buf.WriteByte('"')
@@ -216,6 +220,37 @@ func (f *CSVFormatter) formatCSVField(buf *bytes.Buffer, field string, entry *co
 	return nil
 }
 
+// writeTypedCSVValue writes a typed field, leaving numbers and booleans unquoted
+func (f *CSVFormatter) writeTypedCSVValue(buf *bytes.Buffer, field *core.Field) {
+	if f.MaskSensitiveData && f.isSensitiveField(field.Key) {
+		f.writeCSVValue(buf, f.MaskStringValue)
+		return
+	}
+	if transformer, exists := f.FieldTransformers[field.Key]; exists {
+		f.writeCSVValue(buf, transformer(field.Value()))
+		return
+	}
+	if field.IsNumeric() {
+		field.WriteValue(buf)
+		return
+	}
+
+	tmp := util.GetBufferFromPool()
+	field.WriteValue(tmp)
+	f.writeCSVValueBytes(buf, tmp.Bytes())
+	util.PutBufferToPool(tmp)
+}
+
+// findTypedField returns the typed field with the given key, or nil
+func findTypedField(fields []core.Field, key string) *core.Field {
+	for i := range fields {
+		if fields[i].Key == key {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
 // isSensitiveField checks if a field is in the sensitive fields list
 func (f *CSVFormatter) isSensitiveField(field string) bool {
 	for _, sensitiveField := range f.SensitiveFields {
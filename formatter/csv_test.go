@@ -522,3 +522,53 @@ func TestCSVFormatterWithNonexistentField(t *testing.T) {
 		t.Error("CSVFormatter.Format with nonexistent field produced empty output")
 	}
 }
+
+// TestCSVFormatterTypedFields tests that typed fields can be selected in FieldOrder
+func TestCSVFormatterTypedFields(t *testing.T) {
+	cf := NewCSVFormatter()
+	cf.FieldOrder = []string{"message", "count", "ok", "name"}
+
+	entry := core.GetEntryFromPool()
+	defer core.PutEntryToPool(entry)
+
+	entry.Message = []byte("typed")
+	entry.TypedFields = append(entry.TypedFields,
+		core.Int("count", 7),
+		core.Bool("ok", false),
+		core.String("name", "a,b"),
+	)
+
+	buf := &bytes.Buffer{}
+	if err := cf.Format(buf, entry); err != nil {
+		t.Fatalf("CSVFormatter.Format returned error: %v", err)
+	}
+
+	expected := "typed,7,false,\"a,b\"\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, buf.String())
+	}
+	if strings.Contains(buf.String(), `"7"`) {
+		t.Error("Numeric typed fields should not be quoted")
+	}
+}
+
+// TestCSVFormatterLoggerName tests that the logger name can be selected as a column
+func TestCSVFormatterLoggerName(t *testing.T) {
+	cf := NewCSVFormatter()
+	cf.FieldOrder = []string{"level", "logger", "message"}
+
+	entry := core.GetEntryFromPool()
+	defer core.PutEntryToPool(entry)
+
+	entry.Level = core.WARN
+	entry.Message = []byte("slow query")
+	entry.LoggerName = []byte("db.pool")
+
+	buf := &bytes.Buffer{}
+	if err := cf.Format(buf, entry); err != nil {
+		t.Fatalf("CSVFormatter.Format returned error: %v", err)
+	}
+	if buf.String() != "WARN,db.pool,slow query\n" {
+		t.Errorf("Unexpected CSV output %q", buf.String())
+	}
+}
@@ -269,4 +269,21 @@ func BenchmarkFormatterConcurrent(b *testing.B) {
 			formatter.Format(&buf, entry)
 		}
 	})
-}
\ No newline at end of file
+}
+
+// BenchmarkLogfmtFormatter benchmarks the logfmt formatter
+func BenchmarkLogfmtFormatter(b *testing.B) {
+	formatter := NewLogfmtFormatter()
+	formatter.ShowTraceInfo = true
+
+	entry := createBenchmarkEntry()
+	defer core.PutEntryToPool(entry)
+
+	var buf bytes.Buffer
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		formatter.Format(&buf, entry)
+	}
+}
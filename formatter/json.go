@@ -2,6 +2,8 @@ package formatter
 
 import (
 	"bytes"
+	"encoding/json"
+	"math"
 	"strconv"
 
 	"github.com/Lunar-Chipter/mire/core"
@@ -13,6 +15,7 @@ var (
 	jsonTimestampKey = []byte("\"timestamp\":\"")
 	jsonLevelKey     = []byte("\"level_name\":\"")
 	jsonMessageKey   = []byte("\"message\":\"")
+	jsonLoggerKey    = []byte("\"logger\":\"")
 	jsonPidKey       = []byte(",\"pid\":")
 	jsonCallerKey    = []byte(",\"caller\":\"")
 	jsonGoroutineKey = []byte(",\"goroutine_id\":")
@@ -25,6 +28,7 @@ var (
 	jsonMetricsKey   = []byte(",\"metrics\":")
 	jsonStackKey     = []byte(",\"stack_trace\":")
 	jsonDurationKey  = []byte(",\"duration_ns\":")
+	jsonErrorKey     = []byte(",\"error\":\"")
 	jsonQuote        = []byte("\"")
 	jsonComma        = []byte(",")
 	jsonColon        = []byte(":")
@@ -90,6 +94,14 @@ func (f *JSONFormatter) formatManually(buf *bytes.Buffer, entry *core.LogEntry)
 	buf.Write(jsonQuote)
 	buf.Write(jsonComma)
 
+	// Add logger name if the entry comes from a named logger
+	if len(entry.LoggerName) > 0 {
+		buf.Write(jsonLoggerKey)
+		escapeJSON(buf, entry.LoggerName)
+		buf.Write(jsonQuote)
+		buf.Write(jsonComma)
+	}
+
 	// Add message
 	buf.Write(jsonMessageKey)
 	// Escape the message to handle special characters
@@ -112,9 +124,30 @@ func (f *JSONFormatter) formatManually(buf *bytes.Buffer, entry *core.LogEntry)
 	}
 
 	// Add fields if present
-	if len(entry.Fields) > 0 {
+	if len(entry.Fields) > 0 || len(entry.TypedFields) > 0 {
 		buf.Write(jsonFieldsKey)
-		f.formatFields(buf, entry.Fields)
+		f.formatFields(buf, entry.Fields, entry.TypedFields)
+	}
+
+	if entry.Error != nil {
+		buf.Write(jsonErrorKey)
+		f.writeErrorValue(buf, entry.Error)
+		buf.Write(jsonQuote)
+	}
+
+	if f.EnableDuration && entry.Duration > 0 {
+		buf.Write(jsonDurationKey)
+		util.WriteInt(buf, int64(entry.Duration))
+	}
+
+	if len(entry.Tags) > 0 {
+		buf.Write(jsonTagsKey)
+		f.formatTags(buf, entry.Tags)
+	}
+
+	if len(entry.CustomMetrics) > 0 {
+		buf.Write(jsonMetricsKey)
+		f.formatMetrics(buf, entry.CustomMetrics)
 	}
 
 	// Add trace info if needed - organize in a way that reduces branching
@@ -213,6 +246,15 @@ func (f *JSONFormatter) formatManuallyWithIndent(buf *bytes.Buffer, entry *core.
 	buf.Write(entry.Level.Bytes()) // Using pre-allocated level bytes
 	buf.WriteString("\"")
 
+	// Add logger name if the entry comes from a named logger
+	if len(entry.LoggerName) > 0 {
+		buf.WriteString(",\n  ")
+		indent(1)
+		buf.WriteString("\"logger\": \"")
+		escapeJSON(buf, entry.LoggerName)
+		buf.WriteString("\"")
+	}
+
 	// Add message
 	buf.WriteString(",\n  ")
 	indent(1)
@@ -241,12 +283,41 @@ func (f *JSONFormatter) formatManuallyWithIndent(buf *bytes.Buffer, entry *core.
 	}
 
 	// Add fields if present
-	if len(entry.Fields) > 0 {
+	if len(entry.Fields) > 0 || len(entry.TypedFields) > 0 {
 		buf.WriteString(",\n  ")
 		indent(1)
 		buf.WriteString("\"fields\": ")
 		// For indented fields, we need to format them manually with indentation
-		f.formatFieldsIndented(buf, entry.Fields, 2)
+		f.formatFieldsIndented(buf, entry.Fields, entry.TypedFields, 2)
+	}
+
+	if entry.Error != nil {
+		buf.WriteString(",\n  ")
+		indent(1)
+		buf.WriteString("\"error\": \"")
+		f.writeErrorValue(buf, entry.Error)
+		buf.WriteByte('"')
+	}
+
+	if f.EnableDuration && entry.Duration > 0 {
+		buf.WriteString(",\n  ")
+		indent(1)
+		buf.WriteString("\"duration_ns\": ")
+		util.WriteInt(buf, int64(entry.Duration))
+	}
+
+	if len(entry.Tags) > 0 {
+		buf.WriteString(",\n  ")
+		indent(1)
+		buf.WriteString("\"tags\": ")
+		f.formatTags(buf, entry.Tags)
+	}
+
+	if len(entry.CustomMetrics) > 0 {
+		buf.WriteString(",\n  ")
+		indent(1)
+		buf.WriteString("\"metrics\": ")
+		f.formatMetrics(buf, entry.CustomMetrics)
 	}
 
 	// Add trace info if needed
@@ -289,6 +360,54 @@ func (f *JSONFormatter) formatManuallyWithIndent(buf *bytes.Buffer, entry *core.
 	return nil
 }
 
+// writeErrorValue writes an escaped error message, using ErrorAppender when available
+func (f *JSONFormatter) writeErrorValue(buf *bytes.Buffer, err error) {
+	tmp := util.GetBufferFromPool()
+	if appender, ok := err.(core.ErrorAppender); ok {
+		appender.AppendError(tmp)
+	} else {
+		tmp.WriteString(err.Error())
+	}
+	escapeJSON(buf, tmp.Bytes())
+	util.PutBufferToPool(tmp)
+}
+
+// formatTags writes tags as a JSON array of strings
+func (f *JSONFormatter) formatTags(buf *bytes.Buffer, tags [][]byte) {
+	buf.Write(jsonBracketOpen)
+	for i, tag := range tags {
+		if i > 0 {
+			buf.Write(jsonComma)
+		}
+		buf.Write(jsonQuote)
+		escapeJSON(buf, tag)
+		buf.Write(jsonQuote)
+	}
+	buf.Write(jsonBracketClose)
+}
+
+// formatMetrics writes custom metrics as a JSON object of numbers
+func (f *JSONFormatter) formatMetrics(buf *bytes.Buffer, metrics map[string]float64) {
+	buf.Write(jsonBraceOpen)
+	first := true
+	for k, v := range metrics {
+		if !first {
+			buf.Write(jsonComma)
+		}
+		first = false
+		buf.Write(jsonQuote)
+		escapeJSON(buf, core.StringToBytes(k))
+		buf.Write(jsonQuote)
+		buf.Write(jsonColon)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			buf.WriteString("null") // Not representable as a JSON number
+			continue
+		}
+		buf.Write(strconv.AppendFloat(buf.AvailableBuffer(), v, 'g', -1, 64))
+	}
+	buf.Write(jsonBraceClose)
+}
+
 // escapeJSON escapes special characters in JSON strings
 func escapeJSON(buf *bytes.Buffer, data []byte) {
 	if len(data) == 0 {
@@ -477,8 +596,8 @@ func (f *JSONFormatter) transformValue(val interface{}, defaultVal string) strin
 }
 
 // formatFields formats the fields map in JSON format
-func (f *JSONFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte) {
-	if len(fields) == 0 {
+func (f *JSONFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte, typed []core.Field) {
+	if len(fields) == 0 && len(typed) == 0 {
 		return
 	}
 
@@ -508,11 +627,70 @@ func (f *JSONFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte
 		buf.WriteByte('"')
 	}
 
+	for i := range typed {
+		field := &typed[i]
+		if field.Type == core.UnknownType {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		buf.WriteByte('"')
+		escapeJSON(buf, core.StringToBytes(field.Key))
+		buf.Write([]byte("\":"))
+		f.writeTypedValue(buf, field)
+	}
+
 	buf.Write([]byte("}"))
 }
 
+// writeTypedValue writes a typed field value, keeping numbers and booleans as bare JSON values
+func (f *JSONFormatter) writeTypedValue(buf *bytes.Buffer, field *core.Field) {
+	if f.MaskSensitiveData && f.isSensitiveField(field.Key) {
+		buf.WriteByte('"')
+		buf.Write(f.MaskStringBytes)
+		buf.WriteByte('"')
+		return
+	}
+	if transformer, ok := f.FieldTransformers[field.Key]; ok {
+		f.formatJSONValue(buf, transformer(field.Value()))
+		return
+	}
+	if field.WriteJSONNumber(buf) {
+		return
+	}
+
+	switch field.Type {
+	case core.StringType:
+		buf.WriteByte('"')
+		escapeJSON(buf, core.StringToBytes(field.String))
+		buf.WriteByte('"')
+		return
+	case core.BytesType:
+		buf.WriteByte('"')
+		escapeJSON(buf, field.Bytes)
+		buf.WriteByte('"')
+		return
+	case core.AnyType:
+		// Arbitrary values keep their JSON structure when they can be marshaled
+		if data, err := json.Marshal(field.Interface); err == nil {
+			buf.Write(data)
+			return
+		}
+	}
+
+	tmp := util.GetBufferFromPool()
+	field.WriteValue(tmp)
+	buf.WriteByte('"')
+	escapeJSON(buf, tmp.Bytes())
+	buf.WriteByte('"')
+	util.PutBufferToPool(tmp)
+}
+
 // formatFieldsIndented formats the fields map in JSON format with indentation
-func (f *JSONFormatter) formatFieldsIndented(buf *bytes.Buffer, fields map[string][]byte, indentLevel int) {
+func (f *JSONFormatter) formatFieldsIndented(buf *bytes.Buffer, fields map[string][]byte, typed []core.Field, indentLevel int) {
 	// Pre-allocate indent string to avoid repeated string operations
 	indentBuf := util.GetBufferFromPool()
 	defer util.PutBufferToPool(indentBuf)
@@ -535,7 +713,7 @@ func (f *JSONFormatter) formatFieldsIndented(buf *bytes.Buffer, fields map[strin
 	buf.WriteByte('{')
 
 	// Add a newline after opening brace if there are fields
-	if len(fields) > 0 {
+	if len(fields) > 0 || len(typed) > 0 {
 		// Add one more level of indentation
 		indentBuf.WriteString("  ")
 		indentBytes = indentBuf.Bytes()
@@ -579,6 +757,23 @@ func (f *JSONFormatter) formatFieldsIndented(buf *bytes.Buffer, fields map[strin
 		first = false
 	}
 
+	for i := range typed {
+		field := &typed[i]
+		if field.Type == core.UnknownType {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		fieldNewline()
+
+		buf.WriteByte('"')
+		escapeJSON(buf, core.StringToBytes(field.Key))
+		buf.Write([]byte("\": "))
+		f.writeTypedValue(buf, field)
+		first = false
+	}
+
 	// Add newline and closing brace with proper indentation
 	// Adjust indent level back by one
 	if len(originalIndent) >= 2 {
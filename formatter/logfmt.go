@@ -0,0 +1,329 @@
+package formatter
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/util"
+)
+
+// DEFAULT_LOGFMT_TIMESTAMP_FORMAT is the timestamp format of LogfmtFormatter when none is set
+const DEFAULT_LOGFMT_TIMESTAMP_FORMAT = time.RFC3339Nano
+
+const hexDigits = "0123456789abcdef"
+
+// logfmtLevels contains the lower case level names written by LogfmtFormatter
+var logfmtLevels = func() [][]byte {
+	levels := make([][]byte, len(core.LevelBytes))
+	for i, b := range core.LevelBytes {
+		levels[i] = bytes.ToLower(b)
+	}
+	return levels
+}()
+
+// LogfmtFormatter formats log entries as logfmt key=value pairs, e.g.
+//
+//	ts=2024-05-01T10:00:00Z level=info msg="user created" user=ana
+//
+// Values are quoted when they are empty or contain spaces, '=', '"' or control
+// characters. Keys containing such characters have them replaced by '_'.
+type LogfmtFormatter struct {
+	TimestampFormat   string                              // Custom timestamp format, defaults to DEFAULT_LOGFMT_TIMESTAMP_FORMAT
+	ShowCaller        bool                                // Show caller information
+	ShowGoroutine     bool                                // Show goroutine ID
+	ShowPID           bool                                // Show process ID
+	ShowTraceInfo     bool                                // Show trace, span and request IDs
+	ShowHostname      bool                                // Show hostname
+	ShowApplication   bool                                // Show application name
+	EnableStackTrace  bool                                // Enable stack trace for errors
+	EnableDuration    bool                                // Show operation duration
+	FieldTransformers map[string]func(interface{}) string // Functions to transform field values
+	SensitiveFields   []string                            // List of sensitive field names
+	MaskSensitiveData bool                                // Whether to mask sensitive data
+	MaskStringValue   string                              // String value to use for masking
+	MaskStringBytes   []byte                              // Byte slice for masking (zero-allocation)
+}
+
+// NewLogfmtFormatter creates a new LogfmtFormatter
+func NewLogfmtFormatter() *LogfmtFormatter {
+	return &LogfmtFormatter{
+		MaskStringValue:   "[MASKED]",
+		FieldTransformers: make(map[string]func(interface{}) string),
+		SensitiveFields:   make([]string, 0),
+	}
+}
+
+// Format formats a log entry as a logfmt line without allocations
+func (f *LogfmtFormatter) Format(buf *bytes.Buffer, entry *core.LogEntry) error {
+	format := f.TimestampFormat
+	if format == "" {
+		format = DEFAULT_LOGFMT_TIMESTAMP_FORMAT
+	}
+	buf.WriteString("ts=")
+	start := buf.Len()
+	util.FormatTimestamp(buf, entry.Timestamp, format)
+	quoteFrom(buf, start)
+
+	buf.WriteString(" level=")
+	if entry.Level >= core.TRACE && entry.Level <= core.PANIC {
+		buf.Write(logfmtLevels[entry.Level])
+	} else {
+		writeLogfmtValue(buf, entry.Level.Bytes())
+	}
+
+	if len(entry.LoggerName) > 0 {
+		writeLogfmtPair(buf, "logger", entry.LoggerName)
+	}
+	writeLogfmtPair(buf, "msg", entry.Message)
+
+	f.writeMeta(buf, entry)
+
+	if entry.Error != nil {
+		buf.WriteString(" error=")
+		start := buf.Len()
+		if appender, ok := entry.Error.(core.ErrorAppender); ok {
+			appender.AppendError(buf) // Use zero-allocation append
+		} else {
+			buf.WriteString(entry.Error.Error())
+		}
+		quoteFrom(buf, start)
+	}
+	if f.EnableDuration && entry.Duration > 0 {
+		// Milliseconds with a unit, which Loki parses as a duration
+		buf.WriteString(" duration=")
+		buf.Write(strconv.AppendFloat(buf.AvailableBuffer(), float64(entry.Duration)/float64(time.Millisecond), 'f', -1, 64))
+		buf.WriteString("ms")
+	}
+
+	f.formatFields(buf, entry.Fields, entry.TypedFields)
+
+	if len(entry.Tags) > 0 {
+		buf.WriteString(" tags=")
+		start := buf.Len()
+		for i, tag := range entry.Tags {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(tag)
+		}
+		quoteFrom(buf, start)
+	}
+	for _, k := range sortedKeys(entry.CustomMetrics) {
+		v := entry.CustomMetrics[k]
+		buf.WriteByte(' ')
+		writeLogfmtKey(buf, k)
+		buf.WriteByte('=')
+		buf.Write(strconv.AppendFloat(buf.AvailableBuffer(), v, 'g', -1, 64))
+	}
+	if f.EnableStackTrace && len(entry.StackTrace) > 0 {
+		writeLogfmtPair(buf, "stack", entry.StackTrace)
+	}
+
+	buf.WriteByte('\n')
+	return nil
+}
+
+func (f *LogfmtFormatter) writeMeta(buf *bytes.Buffer, entry *core.LogEntry) {
+	if f.ShowHostname && len(entry.Hostname) > 0 {
+		writeLogfmtPair(buf, "hostname", entry.Hostname)
+	}
+	if f.ShowApplication && len(entry.Application) > 0 {
+		writeLogfmtPair(buf, "app", entry.Application)
+	}
+	if f.ShowPID {
+		buf.WriteString(" pid=")
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(entry.PID), 10))
+	}
+	if f.ShowGoroutine && len(entry.GoroutineID) > 0 {
+		writeLogfmtPair(buf, "goroutine", entry.GoroutineID)
+	}
+	if f.ShowTraceInfo {
+		if len(entry.TraceID) > 0 {
+			writeLogfmtPair(buf, "trace_id", entry.TraceID)
+		}
+		if len(entry.SpanID) > 0 {
+			writeLogfmtPair(buf, "span_id", entry.SpanID)
+		}
+		if len(entry.RequestID) > 0 {
+			writeLogfmtPair(buf, "request_id", entry.RequestID)
+		}
+	}
+	if f.ShowCaller && entry.Caller != nil {
+		buf.WriteString(" caller=")
+		start := buf.Len()
+		buf.WriteString(entry.Caller.File)
+		buf.WriteByte(':')
+		buf.Write(strconv.AppendInt(buf.AvailableBuffer(), int64(entry.Caller.Line), 10))
+		quoteFrom(buf, start)
+	}
+}
+
+// formatFields writes the map fields sorted by key followed by the typed fields in
+// order, each as key=value
+func (f *LogfmtFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte, typed []core.Field) {
+	for _, k := range sortedKeys(fields) {
+		v := fields[k]
+		buf.WriteByte(' ')
+		writeLogfmtKey(buf, k)
+		buf.WriteByte('=')
+		start := buf.Len()
+		if f.MaskSensitiveData && f.isSensitiveField(k) {
+			buf.Write(f.maskBytes())
+		} else if transformer, ok := f.FieldTransformers[k]; ok {
+			buf.WriteString(transformer(string(v)))
+		} else {
+			buf.Write(v)
+		}
+		quoteFrom(buf, start)
+	}
+
+	for i := range typed {
+		field := &typed[i]
+		if field.Type == core.UnknownType {
+			continue
+		}
+		buf.WriteByte(' ')
+		writeLogfmtKey(buf, field.Key)
+		buf.WriteByte('=')
+		start := buf.Len()
+		if f.MaskSensitiveData && f.isSensitiveField(field.Key) {
+			buf.Write(f.maskBytes())
+		} else if transformer, ok := f.FieldTransformers[field.Key]; ok {
+			buf.WriteString(transformer(field.Value()))
+		} else {
+			field.WriteValue(buf)
+		}
+		quoteFrom(buf, start)
+	}
+}
+
+// sortedKeys returns the keys of m in order, so lines are stable. It only allocates
+// when m is not empty.
+func sortedKeys[V any](m map[string]V) []string {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// maskBytes returns the value written in place of sensitive fields
+func (f *LogfmtFormatter) maskBytes() []byte {
+	if len(f.MaskStringBytes) > 0 {
+		return f.MaskStringBytes
+	}
+	if f.MaskStringValue != "" {
+		return core.StringToBytes(f.MaskStringValue)
+	}
+	return []byte("[MASKED]")
+}
+
+func (f *LogfmtFormatter) isSensitiveField(field string) bool {
+	return contains(f.SensitiveFields, field)
+}
+
+// writeLogfmtPair writes " key=value", quoting value if needed
+func writeLogfmtPair(buf *bytes.Buffer, key string, value []byte) {
+	buf.WriteByte(' ')
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	writeLogfmtValue(buf, value)
+}
+
+// writeLogfmtKey writes key, replacing the bytes logfmt does not allow in keys with '_'
+func writeLogfmtKey(buf *bytes.Buffer, key string) {
+	if key == "" {
+		buf.WriteByte('_')
+		return
+	}
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+			buf.WriteByte('_')
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+}
+
+// writeLogfmtValue writes value, quoted and escaped if needed
+func writeLogfmtValue(buf *bytes.Buffer, value []byte) {
+	if !needsLogfmtQuoting(value) {
+		buf.Write(value)
+		return
+	}
+	buf.WriteByte('"')
+	for i := 0; i < len(value); {
+		c := value[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRune(value[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf.WriteString("\uFFFD")
+			} else {
+				buf.Write(value[i : i+size])
+			}
+			i += size
+			continue
+		}
+		switch c {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if c < ' ' || c == 0x7f {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[c>>4])
+				buf.WriteByte(hexDigits[c&0xf])
+			} else {
+				buf.WriteByte(c)
+			}
+		}
+		i++
+	}
+	buf.WriteByte('"')
+}
+
+// needsLogfmtQuoting reports whether value must be quoted to be read back unchanged.
+// Invalid UTF-8 is quoted so it can be replaced with U+FFFD.
+func needsLogfmtQuoting(value []byte) bool {
+	if len(value) == 0 {
+		return true
+	}
+	ascii := true
+	for _, c := range value {
+		if c <= ' ' || c == '=' || c == '"' || c == '\\' || c == 0x7f {
+			return true
+		}
+		if c >= utf8.RuneSelf {
+			ascii = false
+		}
+	}
+	return !ascii && !utf8.Valid(value)
+}
+
+// quoteFrom quotes the value written to buf from start if needed. Values are written
+// unquoted first, so the common case needs no copy.
+func quoteFrom(buf *bytes.Buffer, start int) {
+	value := buf.Bytes()[start:]
+	if !needsLogfmtQuoting(value) {
+		return
+	}
+	scratch := util.GetBufferFromPool()
+	scratch.Write(value)
+	buf.Truncate(start)
+	writeLogfmtValue(buf, scratch.Bytes())
+	util.PutBufferToPool(scratch)
+}
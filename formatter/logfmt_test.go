@@ -0,0 +1,166 @@
+package formatter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// logfmtEntry creates an entry for the logfmt tests
+func logfmtEntry(msg string, fields ...core.Field) *core.LogEntry {
+	return &core.LogEntry{
+		Timestamp:   time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		Level:       core.INFO,
+		Message:     []byte(msg),
+		TypedFields: fields,
+	}
+}
+
+// formatLogfmt formats entry and returns the line without the newline
+func formatLogfmt(t *testing.T, f *LogfmtFormatter, entry *core.LogEntry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := f.Format(&buf, entry); err != nil {
+		t.Fatalf("Format returned error: %v", err)
+	}
+	line := buf.String()
+	if !strings.HasSuffix(line, "\n") {
+		t.Fatalf("Expected a trailing newline, got %q", line)
+	}
+	return strings.TrimSuffix(line, "\n")
+}
+
+// TestLogfmtFormatterFormat tests the layout of a line
+func TestLogfmtFormatterFormat(t *testing.T) {
+	entry := logfmtEntry("user created", core.String("user", "ana"), core.Int("age", 30), core.Bool("admin", false))
+	entry.LoggerName = []byte("api")
+
+	want := `ts=2024-05-01T10:00:00Z level=info logger=api msg="user created" user=ana age=30 admin=false`
+	if got := formatLogfmt(t, NewLogfmtFormatter(), entry); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestLogfmtFormatterOrder tests that map fields and metrics are written sorted by key
+func TestLogfmtFormatterOrder(t *testing.T) {
+	entry := logfmtEntry("ordered", core.String("typed", "last"))
+	entry.Fields = map[string][]byte{"zone": []byte("eu"), "app": []byte("api"), "mode": []byte("fast"), "id": []byte("7")}
+	entry.CustomMetrics = map[string]float64{"rps": 12, "cpu": 0.5, "mem": 64, "disk": 3}
+
+	want := `ts=2024-05-01T10:00:00Z level=info msg=ordered app=api id=7 mode=fast zone=eu typed=last cpu=0.5 disk=3 mem=64 rps=12`
+	for i := 0; i < 20; i++ {
+		if got := formatLogfmt(t, NewLogfmtFormatter(), entry); got != want {
+			t.Fatalf("Expected %q, got %q", want, got)
+		}
+	}
+}
+
+// TestLogfmtFormatterQuoting tests quoting and escaping values and keys
+func TestLogfmtFormatterQuoting(t *testing.T) {
+	for value, want := range map[string]string{
+		"plain":          `plain`,
+		"":               `""`,
+		"two words":      `"two words"`,
+		"a=b":            `"a=b"`,
+		`say "hi"`:       `"say \"hi\""`,
+		`C:\dir`:         `"C:\\dir"`,
+		"line\nbreak\t!": `"line\nbreak\t!"`,
+		"bell\a":         `"bell\u0007"`,
+		"héllo":          `héllo`,
+		"bad\xffbyte":    "\"bad\uFFFDbyte\"",
+	} {
+		got := formatLogfmt(t, &LogfmtFormatter{}, logfmtEntry("m", core.String("v", value)))
+		if want := `ts=2024-05-01T10:00:00Z level=info msg=m v=` + want; got != want {
+			t.Errorf("Value %q: expected %q, got %q", value, want, got)
+		}
+	}
+
+	got := formatLogfmt(t, &LogfmtFormatter{}, logfmtEntry("m", core.String("bad key=", "v"), core.String("", "empty")))
+	if !strings.HasSuffix(got, ` bad_key_=v _=empty`) {
+		t.Errorf("Expected invalid key bytes to be replaced, got %q", got)
+	}
+}
+
+// TestLogfmtFormatterMeta tests the optional metadata
+func TestLogfmtFormatterMeta(t *testing.T) {
+	f := &LogfmtFormatter{
+		TimestampFormat:  time.DateTime,
+		ShowCaller:       true,
+		ShowGoroutine:    true,
+		ShowPID:          true,
+		ShowTraceInfo:    true,
+		ShowHostname:     true,
+		ShowApplication:  true,
+		EnableStackTrace: true,
+		EnableDuration:   true,
+	}
+	entry := logfmtEntry("done")
+	entry.Level = core.ERROR
+	entry.Hostname = []byte("web-1")
+	entry.Application = []byte("billing")
+	entry.PID = 42
+	entry.GoroutineID = []byte("7")
+	entry.TraceID = []byte("0123456789abcdef")
+	entry.SpanID = []byte("span-1")
+	entry.RequestID = []byte("req-1")
+	entry.Caller = &core.CallerInfo{File: "main.go", Line: 12}
+	entry.Error = errors.New("connection refused")
+	entry.Duration = 1500 * time.Microsecond
+	entry.Tags = [][]byte{[]byte("db"), []byte("slow")}
+	entry.CustomMetrics = map[string]float64{"latency": 0.25}
+	entry.StackTrace = []byte("main.main()\n\tmain.go:12")
+
+	want := `ts="2024-05-01 10:00:00" level=error msg=done hostname=web-1 app=billing pid=42 goroutine=7` +
+		` trace_id=0123456789abcdef span_id=span-1 request_id=req-1 caller=main.go:12` +
+		` error="connection refused" duration=1.5ms tags=db,slow latency=0.25 stack="main.main()\n\tmain.go:12"`
+	if got := formatLogfmt(t, f, entry); got != want {
+		t.Errorf("Expected\n%q, got\n%q", want, got)
+	}
+}
+
+// TestLogfmtFormatterMasking tests masking and transforming fields
+func TestLogfmtFormatterMasking(t *testing.T) {
+	f := NewLogfmtFormatter()
+	f.MaskSensitiveData = true
+	f.SensitiveFields = []string{"password", "token"}
+	f.FieldTransformers["user"] = func(v interface{}) string { return strings.ToUpper(v.(string)) }
+
+	entry := logfmtEntry("login", core.String("user", "ana"), core.String("password", "secret"))
+	entry.Fields = map[string][]byte{"token": []byte("abc")}
+	got := formatLogfmt(t, f, entry)
+	for _, want := range []string{" token=[MASKED]", " user=ANA", " password=[MASKED]"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in %q", want, got)
+		}
+	}
+	if strings.Contains(got, "secret") || strings.Contains(got, "abc") {
+		t.Errorf("Expected sensitive values to be masked, got %q", got)
+	}
+
+	f.MaskStringBytes = []byte("***")
+	if got := formatLogfmt(t, f, entry); !strings.Contains(got, " password=***") {
+		t.Errorf("Expected the mask bytes to be used, got %q", got)
+	}
+}
+
+// TestLogfmtFormatterAllocations tests that formatting does not allocate
+func TestLogfmtFormatterAllocations(t *testing.T) {
+	f := NewLogfmtFormatter()
+	f.ShowCaller = true
+	entry := logfmtEntry("request served", core.String("path", "/users"), core.String("agent", "curl 8.0"), core.Int("status", 200))
+	entry.Caller = &core.CallerInfo{File: "main.go", Line: 12}
+	var buf bytes.Buffer
+	f.Format(&buf, entry)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf.Reset()
+		f.Format(&buf, entry)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+}
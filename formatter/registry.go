@@ -0,0 +1,47 @@
+package formatter
+
+import (
+	"sort"
+	"sync"
+)
+
+// Factory creates a formatter with its default settings
+type Factory func() Formatter
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"text":   func() Formatter { return NewTextFormatter() },
+		"json":   func() Formatter { return NewJSONFormatter() },
+		"csv":    func() Formatter { return NewCSVFormatter() },
+		"logfmt": func() Formatter { return NewLogfmtFormatter() },
+	}
+)
+
+// Register makes a formatter available by name, e.g. to logger config files.
+// Registering an existing name replaces its factory.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// Lookup returns the factory registered under name
+func Lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// Names returns the sorted names of all registered formatters
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
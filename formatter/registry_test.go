@@ -0,0 +1,66 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// upperFormatter is a custom formatter for registry tests
+type upperFormatter struct{}
+
+func (upperFormatter) Format(buf *bytes.Buffer, entry *core.LogEntry) error {
+	buf.Write(bytes.ToUpper(entry.Message))
+	return nil
+}
+
+// TestRegistryBuiltins tests the formatters registered by default
+func TestRegistryBuiltins(t *testing.T) {
+	for name, want := range map[string]Formatter{"text": &TextFormatter{}, "json": &JSONFormatter{}, "csv": &CSVFormatter{}, "logfmt": &LogfmtFormatter{}} {
+		factory, ok := Lookup(name)
+		if !ok {
+			t.Fatalf("Expected %s formatter to be registered", name)
+		}
+		got := factory()
+		switch want.(type) {
+		case *TextFormatter:
+			_, ok = got.(*TextFormatter)
+		case *JSONFormatter:
+			_, ok = got.(*JSONFormatter)
+		case *CSVFormatter:
+			_, ok = got.(*CSVFormatter)
+		case *LogfmtFormatter:
+			_, ok = got.(*LogfmtFormatter)
+		}
+		if !ok {
+			t.Errorf("Expected %s factory to create %T, got %T", name, want, got)
+		}
+	}
+	if factory, _ := Lookup("text"); factory() == factory() {
+		t.Error("Expected every call to create a new formatter")
+	}
+}
+
+// TestRegistryRegister tests registering a custom formatter
+func TestRegistryRegister(t *testing.T) {
+	if _, ok := Lookup("upper"); ok {
+		t.Fatal("Unexpected upper formatter before registration")
+	}
+	Register("upper", func() Formatter { return upperFormatter{} })
+
+	factory, ok := Lookup("upper")
+	if !ok {
+		t.Fatal("Expected upper formatter after registration")
+	}
+	entry := &core.LogEntry{Message: []byte("hi")}
+	var buf bytes.Buffer
+	if err := factory().Format(&buf, entry); err != nil || buf.String() != "HI" {
+		t.Errorf("Expected HI, got %q (%v)", buf.String(), err)
+	}
+
+	names := Names()
+	if len(names) != 5 || names[0] != "csv" || names[4] != "upper" {
+		t.Errorf("Expected sorted names including upper, got %v", names)
+	}
+}
@@ -67,7 +67,7 @@ func (f *TextFormatter) Format(buf *bytes.Buffer, entry *core.LogEntry) error {
 		estimatedSize += len(f.TimestampFormat) + 10 // Extra for formatting overhead
 	}
 	estimatedSize += len(entry.Level.Bytes())
-	if len(entry.Fields) > 0 {
+	if len(entry.Fields) > 0 || len(entry.TypedFields) > 0 {
 		estimatedSize += 64 // Estimate for fields formatting
 	}
 
@@ -126,6 +126,17 @@ func (f *TextFormatter) Format(buf *bytes.Buffer, entry *core.LogEntry) error {
 }
 
 func (f *TextFormatter) writeMeta(buf *bytes.Buffer, entry *core.LogEntry) {
+	if len(entry.LoggerName) > 0 {
+		if f.EnableColors {
+			buf.Write(metaColorBytes)
+		}
+		buf.Write([]byte("logger="))
+		buf.Write(entry.LoggerName)
+		if f.EnableColors {
+			buf.Write(ResetColorBytes)
+		}
+		buf.WriteByte(' ')
+	}
 	if f.ShowHostname && entry.Hostname != nil {
 		f.writeMetaPartBytes(buf, entry.Hostname)
 	}
@@ -273,9 +284,9 @@ func (f *TextFormatter) writePostMessage(buf *bytes.Buffer, entry *core.LogEntry
 		}
 	}
 
-	if len(entry.Fields) > 0 {
+	if len(entry.Fields) > 0 || len(entry.TypedFields) > 0 {
 		buf.WriteByte(' ')
-		f.formatFields(buf, entry.Fields)
+		f.formatFields(buf, entry.Fields, entry.TypedFields)
 	}
 	if len(entry.Tags) > 0 {
 		buf.WriteByte(' ')
@@ -297,7 +308,7 @@ func (f *TextFormatter) writePostMessage(buf *bytes.Buffer, entry *core.LogEntry
 	}
 }
 
-func (f *TextFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte) {
+func (f *TextFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte, typed []core.Field) {
 	if f.EnableColors {
 		buf.Write(fieldsWrapperColorBytes)
 	}
@@ -335,6 +346,36 @@ func (f *TextFormatter) formatFields(buf *bytes.Buffer, fields map[string][]byte
 		}
 	}
 
+	// Typed fields follow the byte fields and keep their native formatting
+	written := len(orderedKeys)
+	for i := range typed {
+		field := &typed[i]
+		if field.Type == core.UnknownType {
+			continue
+		}
+		if written > 0 {
+			buf.WriteByte(' ')
+		}
+		written++
+
+		if f.EnableColors {
+			buf.Write(fieldKeyColorBytes)
+		}
+		buf.Write(core.StringToBytes(field.Key))
+		buf.WriteByte('=')
+		if f.EnableColors {
+			buf.Write(fieldValueColorBytes)
+		}
+
+		if f.MaskSensitiveData && f.isSensitiveField(field.Key) {
+			buf.Write(f.MaskStringBytes)
+		} else if transformer, ok := f.FieldTransformers[field.Key]; ok {
+			buf.WriteString(transformer(field.Value()))
+		} else {
+			field.WriteValue(buf)
+		}
+	}
+
 	if f.EnableColors {
 		buf.Write(fieldsWrapperColorBytes)
 	}
@@ -536,7 +536,7 @@ func TestTextFormatterFormatFields(t *testing.T) {
 		"float_field":  []byte("3.14"),
 	}
 	
-	tf.formatFields(buf, fields)
+	tf.formatFields(buf, fields, nil)
 	
 	output := buf.String()
 	if len(output) == 0 {
@@ -626,4 +626,58 @@ func TestTextFormatterManualFormatTimestamp(t *testing.T) {
 	if len(output) == 0 {
 		t.Error("manualFormatTimestamp produced empty output")
 	}
-}
\ No newline at end of file
+}
+// TestTextFormatterTypedFields tests that typed fields share the braces with byte fields
+func TestTextFormatterTypedFields(t *testing.T) {
+	tf := NewTextFormatter()
+	tf.SensitiveFields = []string{"password"}
+	tf.MaskSensitiveData = true
+	tf.MaskStringBytes = []byte("[MASKED]")
+
+	entry := core.GetEntryFromPool()
+	defer core.PutEntryToPool(entry)
+
+	entry.Level = core.INFO
+	entry.Message = []byte("typed")
+	entry.Fields["user"] = []byte("alice")
+	entry.TypedFields = append(entry.TypedFields,
+		core.Int("attempts", 3),
+		core.Duration("elapsed", 250*time.Millisecond),
+		core.String("password", "hunter2"),
+	)
+
+	buf := &bytes.Buffer{}
+	if err := tf.Format(buf, entry); err != nil {
+		t.Fatalf("TextFormatter.Format returned error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"user=alice", "attempts=3", "elapsed=250ms", "password=[MASKED]"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("Expected %q in output, got %q", want, output)
+		}
+	}
+	if bytes.Count(buf.Bytes(), []byte("{")) != 1 {
+		t.Errorf("Expected a single fields block, got %q", output)
+	}
+}
+
+// TestTextFormatterLoggerName tests that the logger name is written as a logger field
+func TestTextFormatterLoggerName(t *testing.T) {
+	tf := NewTextFormatter()
+
+	entry := core.GetEntryFromPool()
+	defer core.PutEntryToPool(entry)
+
+	entry.Level = core.INFO
+	entry.Message = []byte("connected")
+	entry.LoggerName = []byte("db.pool")
+
+	buf := &bytes.Buffer{}
+	if err := tf.Format(buf, entry); err != nil {
+		t.Fatalf("TextFormatter.Format returned error: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("[INFO] logger=db.pool connected")) {
+		t.Errorf("Expected logger name before the message, got %q", buf.String())
+	}
+}
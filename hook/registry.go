@@ -0,0 +1,67 @@
+package hook
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// Factory creates a hook from its JSON options, e.g. for logger config files
+type Factory func(options json.RawMessage) (Hook, error)
+
+// fileHookOptions are the options of the "file" hook
+type fileHookOptions struct {
+	Path string `json:"path"` // File to append entries to
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"file": newFileHookFromOptions,
+	}
+)
+
+// newFileHookFromOptions creates a SimpleFileHook from {"path": "..."}
+func newFileHookFromOptions(options json.RawMessage) (Hook, error) {
+	var opts fileHookOptions
+	if len(options) > 0 {
+		if err := json.Unmarshal(options, &opts); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Path == "" {
+		return nil, &wrappedError{msg: "file hook requires a path"}
+	}
+	h, err := NewFileHook(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Register makes a hook available by name. Registering an existing name replaces its factory.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// Lookup returns the factory registered under name
+func Lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// Names returns the sorted names of all registered hooks
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
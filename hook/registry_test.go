@@ -0,0 +1,56 @@
+package hook
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// nopHook is a custom hook for registry tests
+type nopHook struct{}
+
+func (nopHook) Fire(entry *core.LogEntry) error { return nil }
+func (nopHook) Close() error                    { return nil }
+
+// TestRegistryFileHook tests the built-in file hook factory
+func TestRegistryFileHook(t *testing.T) {
+	factory, ok := Lookup("file")
+	if !ok {
+		t.Fatal("Expected the file hook to be registered")
+	}
+
+	path := filepath.Join(t.TempDir(), "errors.log")
+	h, err := factory(json.RawMessage(`{"path": "` + filepath.ToSlash(path) + `"}`))
+	if err != nil {
+		t.Fatalf("Factory returned error: %v", err)
+	}
+	if _, ok := h.(*SimpleFileHook); !ok {
+		t.Errorf("Expected *SimpleFileHook, got %T", h)
+	}
+	h.Close()
+
+	if h, err := factory(nil); err == nil || h != nil {
+		t.Errorf("Expected an error without a path, got %v %v", h, err)
+	}
+	if h, err := factory(json.RawMessage(`{"path": "/invalid/path/that/does/not/exist/file.log"}`)); err == nil || h != nil {
+		t.Errorf("Expected an error for an invalid path, got %v %v", h, err)
+	}
+}
+
+// TestRegistryRegister tests registering a custom hook
+func TestRegistryRegister(t *testing.T) {
+	Register("nop", func(json.RawMessage) (Hook, error) { return nopHook{}, nil })
+
+	factory, ok := Lookup("nop")
+	if !ok {
+		t.Fatal("Expected the nop hook after registration")
+	}
+	if h, err := factory(nil); err != nil || h != (nopHook{}) {
+		t.Errorf("Unexpected hook %v %v", h, err)
+	}
+	if names := Names(); len(names) != 2 || names[0] != "file" || names[1] != "nop" {
+		t.Errorf("Expected sorted names, got %v", names)
+	}
+}
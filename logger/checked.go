@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// CheckedEntry is an entry that passed the level, sampling and closed checks of
+// Logger.Check. Expensive fields can be computed after the check and passed to Write:
+//
+//	if ce := l.Check(core.DEBUG, "cache state"); ce != nil {
+//		ce.Write(core.Any("snapshot", cache.Snapshot()))
+//	}
+//
+// A CheckedEntry must be written exactly once and must not be used afterwards.
+type CheckedEntry struct {
+	logger *Logger
+	entry  *core.LogEntry
+}
+
+// checkedEntryPool reuses CheckedEntry objects between log calls
+var checkedEntryPool = sync.Pool{
+	New: func() interface{} {
+		return &CheckedEntry{}
+	},
+}
+
+// Check returns a CheckedEntry if an entry with the given level and message would be
+// logged, or nil if it is filtered by level, level rules, sampling or because the
+// logger is closed
+func (l *Logger) Check(level core.Level, msg string) *CheckedEntry {
+	return l.check(context.Background(), level, msg)
+}
+
+// CheckC is the context-aware version of Check
+func (l *Logger) CheckC(ctx context.Context, level core.Level, msg string) *CheckedEntry {
+	return l.check(ctx, level, msg)
+}
+
+// check runs the same checks as Logger.log and builds the entry without the call's fields
+func (l *Logger) check(ctx context.Context, level core.Level, msg string) *CheckedEntry {
+	l, ctxFields := l.resolveContextLogger(ctx, nil)
+
+	if l.closed.Load() {
+		return nil
+	}
+	if !l.levelEnabled(level, 0, logCallerSkip) {
+		return nil
+	}
+	if l.sampledOut() {
+		return nil
+	}
+
+	ce := checkedEntryPool.Get().(*CheckedEntry)
+	ce.logger = l
+	ce.entry = l.buildEntryByte(ctx, level, core.StringToBytes(msg), nil, ctxFields)
+	ce.entry.PC = l.samplerPC(0, logCallerSkip)
+	return ce
+}
+
+// Write adds the fields to the entry and logs it. Calling Write on a nil
+// CheckedEntry does nothing.
+func (ce *CheckedEntry) Write(fields ...core.Field) {
+	if ce == nil {
+		return
+	}
+	l, entry := ce.logger, ce.entry
+	ce.logger, ce.entry = nil, nil
+	checkedEntryPool.Put(ce)
+
+	for i := range fields {
+		addTypedField(entry, fields[i])
+	}
+	l.dispatchEntry(entry)
+}
@@ -0,0 +1,82 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// TestCheckWrite tests that a checked entry is written with the fields passed to Write
+func TestCheckWrite(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+	defer l.Close()
+
+	computed := false
+	if ce := l.Check(core.DEBUG, "filtered"); ce != nil {
+		computed = true
+		ce.Write(core.Int("n", 1))
+	}
+	if computed {
+		t.Error("Check should return nil for a disabled level")
+	}
+
+	ce := l.Check(core.WARN, "cache state")
+	if ce == nil {
+		t.Fatal("Check should return an entry for an enabled level")
+	}
+	ce.Write(core.Int("size", 42), core.String("state", "warm"))
+
+	output := buf.String()
+	for _, want := range []string{`"message":"cache state"`, `"size":42`, `"state":"warm"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in output %s", want, output)
+		}
+	}
+	if strings.Contains(output, "filtered") {
+		t.Errorf("Filtered entry was written: %s", output)
+	}
+
+	// Writing a nil entry is a no-op
+	var nilEntry *CheckedEntry
+	nilEntry.Write(core.Int("n", 1))
+}
+
+// TestCheckFilters tests that Check honours sampling, level rules and the closed flag
+func TestCheckFilters(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{
+		Level:          core.INFO,
+		Output:         &buf,
+		Formatter:      formatter.NewJSONFormatter(),
+		EnableSampling: true,
+		SamplingRate:   2,
+		LevelRules:     []LevelRule{{File: "checked_test.go", Level: core.DEBUG}},
+	})
+
+	checked := 0
+	for i := 0; i < 10; i++ {
+		if ce := l.Check(core.DEBUG, "sampled debug"); ce != nil {
+			checked++
+			ce.Write()
+		}
+	}
+	if checked > 6 || checked < 4 {
+		t.Errorf("Expected about 5 checked entries with sampling rate 2, got %d", checked)
+	}
+	if got := strings.Count(buf.String(), "sampled debug"); got != checked {
+		t.Errorf("Expected %d written entries, got %d", checked, got)
+	}
+
+	l.Close()
+	if l.Check(core.ERROR, "closed") != nil {
+		t.Error("Check should return nil after Close")
+	}
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// contextKey is a type for context keys to avoid collisions
+type contextKey int
+
+const (
+	// loggerContextKey is the context key for the request-scoped logger
+	loggerContextKey contextKey = iota
+	// fieldsContextKey is the context key for fields added with WithContextFields
+	fieldsContextKey
+)
+
+var (
+	defaultContextLogger     atomic.Pointer[Logger] // Logger returned by FromContext when the context has none
+	defaultContextLoggerOnce sync.Once
+)
+
+// IntoContext returns a copy of ctx that carries the logger
+func IntoContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, l)
+}
+
+// FromContext returns the logger stored in ctx, or the default context logger when
+// ctx carries none. Fields added with WithContextFields are attached to the result.
+func FromContext(ctx context.Context) *Logger {
+	l := loggerFromContext(ctx)
+	if l == nil {
+		l = DefaultContextLogger()
+	}
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		return l.With(fields...)
+	}
+	return l
+}
+
+// WithContextFields returns a copy of ctx with additional fields. Downstream code that
+// logs through FromContext, or through the *C methods of a logger with
+// UseContextLogger enabled, includes these fields.
+func WithContextFields(ctx context.Context, fields ...core.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	existing := ContextFields(ctx)
+	// Always copy, the existing slice is shared with the parent context
+	merged := make([]core.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey, merged)
+}
+
+// ContextFields returns the fields added to ctx with WithContextFields.
+// The returned slice must not be modified.
+func ContextFields(ctx context.Context) []core.Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsContextKey).([]core.Field)
+	return fields
+}
+
+// SetDefaultContextLogger sets the logger that FromContext returns for contexts without a logger
+func SetDefaultContextLogger(l *Logger) {
+	defaultContextLogger.Store(l)
+}
+
+// DefaultContextLogger returns the logger set with SetDefaultContextLogger.
+// If none was set, a logger created with NewDefaultLogger is used.
+func DefaultContextLogger() *Logger {
+	if l := defaultContextLogger.Load(); l != nil {
+		return l
+	}
+	defaultContextLoggerOnce.Do(func() {
+		l := NewDefaultLogger()
+		if !defaultContextLogger.CompareAndSwap(nil, l) {
+			l.Close() // A default was set concurrently
+		}
+	})
+	return defaultContextLogger.Load()
+}
+
+// loggerFromContext returns the logger stored in ctx, or nil
+func loggerFromContext(ctx context.Context) *Logger {
+	if ctx == nil {
+		return nil
+	}
+	l, _ := ctx.Value(loggerContextKey).(*Logger)
+	return l
+}
+
+// resolveContextLogger returns the logger and extra fields to use for a *C call.
+// With UseContextLogger enabled, the logger stored in ctx replaces l and the fields
+// added with WithContextFields are placed before the call's own typed fields.
+func (l *Logger) resolveContextLogger(ctx context.Context, typed []core.Field) (*Logger, []core.Field) {
+	if !l.Config.UseContextLogger || ctx == nil {
+		return l, typed
+	}
+	if cl := loggerFromContext(ctx); cl != nil {
+		l = cl
+	}
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		merged := make([]core.Field, 0, len(fields)+len(typed))
+		merged = append(merged, fields...)
+		typed = append(merged, typed...)
+	}
+	return l, typed
+}
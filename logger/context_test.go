@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// newContextTestLogger creates a JSON logger writing to buf
+func newContextTestLogger(buf *bytes.Buffer, useContextLogger bool) *Logger {
+	return New(LoggerConfig{
+		Level:            core.INFO,
+		Output:           buf,
+		Formatter:        formatter.NewJSONFormatter(),
+		UseContextLogger: useContextLogger,
+	})
+}
+
+// TestIntoContextFromContext tests storing a logger in a context and reading it back
+func TestIntoContextFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := newContextTestLogger(&buf, false)
+	defer l.Close()
+
+	ctx := IntoContext(context.Background(), l)
+	if FromContext(ctx) != l {
+		t.Error("FromContext should return the stored logger")
+	}
+
+	// Fields added downstream flow through FromContext
+	ctx = WithContextFields(ctx, core.String("request_id", "r-1"))
+	ctx = WithContextFields(ctx, core.Int("attempt", 2))
+	FromContext(ctx).Info("handled")
+
+	output := buf.String()
+	for _, want := range []string{`"request_id":"r-1"`, `"attempt":2`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in output %s", want, output)
+		}
+	}
+}
+
+// TestWithContextFieldsDoesNotLeak tests that sibling contexts do not share appended fields
+func TestWithContextFieldsDoesNotLeak(t *testing.T) {
+	parent := WithContextFields(context.Background(), core.String("a", "1"))
+	left := WithContextFields(parent, core.String("b", "2"))
+	right := WithContextFields(parent, core.String("c", "3"))
+
+	if len(ContextFields(parent)) != 1 {
+		t.Errorf("Parent fields changed: %v", ContextFields(parent))
+	}
+	if got := ContextFields(left); len(got) != 2 || got[1].Key != "b" {
+		t.Errorf("Unexpected left fields %v", got)
+	}
+	if got := ContextFields(right); len(got) != 2 || got[1].Key != "c" {
+		t.Errorf("Unexpected right fields %v", got)
+	}
+}
+
+// TestDefaultContextLogger tests the fallback for contexts without a logger
+func TestDefaultContextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := newContextTestLogger(&buf, false)
+	defer l.Close()
+
+	previous := defaultContextLogger.Load()
+	SetDefaultContextLogger(l)
+	defer defaultContextLogger.Store(previous)
+
+	if FromContext(context.Background()) != l {
+		t.Error("FromContext should fall back to the default context logger")
+	}
+}
+
+// TestUseContextLogger tests that the *C methods use the context's logger and fields
+func TestUseContextLogger(t *testing.T) {
+	var appBuf, reqBuf bytes.Buffer
+	app := newContextTestLogger(&appBuf, true)
+	defer app.Close()
+	req := newContextTestLogger(&reqBuf, false).Named("request")
+	defer req.Close()
+
+	ctx := IntoContext(context.Background(), req)
+	ctx = WithContextFields(ctx, core.String("user", "alice"))
+
+	app.InfoC(ctx, "via context logger")
+	app.InfoFieldsC(ctx, "typed via context logger", core.Int("status", 200))
+	app.Info("not a context method")
+
+	if strings.Contains(appBuf.String(), "via context logger") {
+		t.Errorf("*C calls should be routed to the context logger, app got %s", appBuf.String())
+	}
+	if !strings.Contains(appBuf.String(), "not a context method") {
+		t.Errorf("Non-context calls should use the app logger, got %s", appBuf.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(reqBuf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines from the context logger, got %d: %s", len(lines), reqBuf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"logger":"request"`) || !strings.Contains(line, `"user":"alice"`) {
+			t.Errorf("Expected context logger name and fields in %s", line)
+		}
+	}
+	if !strings.Contains(lines[1], `"status":200`) {
+		t.Errorf("Expected call fields in %s", lines[1])
+	}
+}
+
+// TestUseContextLoggerDisabled tests that the context is ignored without the option
+func TestUseContextLoggerDisabled(t *testing.T) {
+	var appBuf, reqBuf bytes.Buffer
+	app := newContextTestLogger(&appBuf, false)
+	defer app.Close()
+	req := newContextTestLogger(&reqBuf, false)
+	defer req.Close()
+
+	ctx := WithContextFields(IntoContext(context.Background(), req), core.String("user", "alice"))
+	app.InfoC(ctx, "stays on app")
+
+	if reqBuf.Len() > 0 || strings.Contains(appBuf.String(), "alice") {
+		t.Errorf("Context logger and fields should be ignored, app=%s req=%s", appBuf.String(), reqBuf.String())
+	}
+}
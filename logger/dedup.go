@@ -0,0 +1,136 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// deduplicator collapses identical consecutive messages, see LoggerConfig.DuplicateWindow.
+// It is shared by the logger and all loggers derived from it, so a message repeated
+// through several derived loggers is still collapsed. Only the leveled methods such as
+// Info, Infof and InfoFields are deduplicated; events, checked entries and the standard
+// library adapter build their message after the call site is gone.
+type deduplicator struct {
+	window   time.Duration
+	mu       sync.Mutex
+	logger   *Logger     // Logger of the last emitted message, writes the summary
+	level    core.Level  // Level of the last emitted message
+	pc       uintptr     // Call site of the last emitted message
+	message  []byte      // Copy of the last emitted message
+	first    time.Time   // When the last emitted message was logged
+	repeated int         // Number of suppressed repetitions of the last emitted message
+	seq      uint64      // Incremented for every emitted message, identifies the pending summary
+	timer    *time.Timer // Writes the pending summary when the window ends
+}
+
+// newDeduplicator creates a deduplicator, or returns nil if window is not positive
+func newDeduplicator(window time.Duration) *deduplicator {
+	if window <= 0 {
+		return nil
+	}
+	return &deduplicator{window: window}
+}
+
+// duplicate reports whether the log call at level with message repeats the last
+// emitted message from the same call site within the window and must be dropped.
+// A pending summary of the previous message is written first when the message differs
+// or the window has passed, and otherwise when the window ends.
+// The call site is looked up skip frames above the function
+// that called duplicate. FATAL and PANIC entries are never dropped, the pending
+// summary is written before them.
+func (l *Logger) duplicate(level core.Level, message []byte, skip int) bool {
+	d := l.dedup
+	if d == nil {
+		return false
+	}
+	if level >= core.FATAL {
+		l.flushDuplicates()
+		return false
+	}
+
+	var pcs [1]uintptr
+	var pc uintptr
+	if runtime.Callers(skip+2+l.callerSkip, pcs[:]) > 0 {
+		pc = pcs[0]
+	}
+	now := time.Now()
+
+	d.mu.Lock()
+	if d.logger != nil && d.level == level && d.pc == pc && now.Sub(d.first) < d.window && bytes.Equal(d.message, message) {
+		d.repeated++
+		if d.repeated == 1 {
+			seq := d.seq
+			d.timer = time.AfterFunc(d.first.Add(d.window).Sub(now), func() { d.flush(seq) })
+		}
+		d.mu.Unlock()
+		return true
+	}
+	prev, prevLevel, repeated := d.logger, d.level, d.repeated
+	d.stopTimer()
+	d.logger, d.level, d.pc, d.first, d.repeated = l, level, pc, now, 0
+	d.seq++
+	d.message = append(d.message[:0], message...)
+	d.mu.Unlock()
+
+	if repeated > 0 {
+		prev.writeRepeated(prevLevel, repeated)
+	}
+	return false
+}
+
+// flushDuplicates writes the summary of the suppressed repetitions, if any
+func (l *Logger) flushDuplicates() {
+	if l.dedup != nil {
+		l.dedup.flush(0)
+	}
+}
+
+// flush writes the pending summary and forgets the last emitted message. A nonzero
+// seq only flushes the summary of that message.
+func (d *deduplicator) flush(seq uint64) {
+	d.mu.Lock()
+	if seq != 0 && seq != d.seq {
+		d.mu.Unlock()
+		return
+	}
+	prev, level, repeated := d.logger, d.level, d.repeated
+	d.stopTimer()
+	d.logger, d.repeated = nil, 0
+	d.mu.Unlock()
+
+	if repeated > 0 {
+		prev.writeRepeated(level, repeated)
+	}
+}
+
+// stopTimer stops the timer of the pending summary. d.mu must be held.
+func (d *deduplicator) stopTimer() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+}
+
+// writeRepeated writes the summary line for n suppressed repetitions at level
+func (l *Logger) writeRepeated(level core.Level, n int) {
+	msg := make([]byte, 0, 40)
+	msg = append(msg, "last message repeated "...)
+	msg = strconv.AppendInt(msg, int64(n), 10)
+	if n == 1 {
+		msg = append(msg, " time"...)
+	} else {
+		msg = append(msg, " times"...)
+	}
+
+	if async := l.asyncFor(level); async != nil {
+		async.LogFields(level, msg, nil, nil, context.Background())
+		return
+	}
+	l.writeFields(context.Background(), level, msg, nil, nil)
+}
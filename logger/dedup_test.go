@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// newDedupLogger creates a logger writing text without timestamps with the given window
+func newDedupLogger(out *lockedBuffer, window time.Duration) *Logger {
+	return New(LoggerConfig{Level: core.DEBUG, Output: out, Formatter: &formatter.TextFormatter{}, DuplicateWindow: window})
+}
+
+// lines returns the non-empty lines written to buf
+func lines(buf *lockedBuffer) []string {
+	return strings.Split(strings.TrimSpace(buf.String()), "\n")
+}
+
+// TestDuplicateSuppression tests collapsing repeated messages from one call site
+func TestDuplicateSuppression(t *testing.T) {
+	var buf lockedBuffer
+	l := newDedupLogger(&buf, time.Minute)
+	defer l.Close()
+
+	for i := 0; i < 5; i++ {
+		l.Warn("retrying")
+	}
+	l.Info("done")
+
+	want := []string{"[WARN] retrying", "[WARN] last message repeated 4 times", "[INFO] done"}
+	if got := lines(&buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestDuplicateKeys tests that the level, the call site and the message must all match
+func TestDuplicateKeys(t *testing.T) {
+	var buf lockedBuffer
+	l := newDedupLogger(&buf, time.Minute)
+	defer l.Close()
+
+	l.Info("same")
+	l.Info("same") // Another call site
+	for _, level := range []core.Level{core.INFO, core.WARN} {
+		l.logFields(nil, level, []byte("same"), nil, nil)
+	}
+	for i := 0; i < 2; i++ {
+		l.InfoFields("fields", core.Int("i", i)) // Fields are not compared
+	}
+	l.Info("end")
+
+	if got := lines(&buf); len(got) != 7 || got[5] != "[INFO] last message repeated 1 time" {
+		t.Errorf("Unexpected output %q", got)
+	}
+}
+
+// TestDuplicateWindow tests that a repetition after the window is written again
+func TestDuplicateWindow(t *testing.T) {
+	var buf lockedBuffer
+	l := newDedupLogger(&buf, 20*time.Millisecond)
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		if i == 2 {
+			time.Sleep(30 * time.Millisecond)
+		}
+		l.Info("tick")
+	}
+
+	want := []string{"[INFO] tick", "[INFO] last message repeated 1 time", "[INFO] tick"}
+	if got := lines(&buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestDuplicateFlushOnClose tests that Close writes the pending summary
+func TestDuplicateFlushOnClose(t *testing.T) {
+	var buf lockedBuffer
+	l := newDedupLogger(&buf, time.Minute)
+	child := l.Named("child")
+
+	for i := 0; i < 3; i++ {
+		child.Error("failed")
+	}
+	l.Close()
+
+	if got := buf.String(); !strings.Contains(got, "logger=child last message repeated 2 times") {
+		t.Errorf("Expected the summary through the child logger, got %q", got)
+	}
+}
+
+// TestDuplicateFlushOnSync tests that Sync writes the pending summary
+func TestDuplicateFlushOnSync(t *testing.T) {
+	var buf lockedBuffer
+	l := newDedupLogger(&buf, time.Minute)
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		l.Warn("retrying")
+	}
+	if err := l.Sync(); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	want := []string{"[WARN] retrying", "[WARN] last message repeated 2 times"}
+	if got := lines(&buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestDuplicateWindowExpiry tests that the summary is written when the window ends
+// without another message
+func TestDuplicateWindowExpiry(t *testing.T) {
+	var buf lockedBuffer
+	l := newDedupLogger(&buf, 20*time.Millisecond)
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		l.Warn("retrying")
+	}
+
+	waitFor(t, "the summary", func() bool {
+		return strings.Contains(buf.String(), "[WARN] last message repeated 2 times")
+	})
+	l.Warn("retrying")
+	l.Sync()
+	if got := lines(&buf); len(got) != 3 || got[2] != "[WARN] retrying" {
+		t.Errorf("Expected the message again after the summary, got %q", got)
+	}
+}
+
+// TestDuplicateBeforeFatal tests that the pending summary is written before a FATAL entry
+func TestDuplicateBeforeFatal(t *testing.T) {
+	var buf lockedBuffer
+	l := New(LoggerConfig{Level: core.INFO, Output: &buf, Formatter: &formatter.TextFormatter{}, DuplicateWindow: time.Minute, ExitFunc: func(int) {}})
+	defer l.Close()
+
+	for i := 0; i < 2; i++ {
+		l.Error("failed")
+	}
+	l.Fatal("giving up")
+
+	want := []string{"[ERROR] failed", "[ERROR] last message repeated 1 time", "[FATAL] giving up"}
+	if got := lines(&buf); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestDuplicateAsync tests suppression with async logging
+func TestDuplicateAsync(t *testing.T) {
+	var buf lockedBuffer
+	l := New(LoggerConfig{
+		Level:                     core.INFO,
+		Output:                    &buf,
+		Formatter:                 &formatter.TextFormatter{},
+		DuplicateWindow:           time.Minute,
+		AsyncLogging:              true,
+		AsyncWorkerCount:          1,
+		AsyncLogChannelBufferSize: 200,
+	})
+
+	for i := 0; i < 100; i++ {
+		l.Info("queued")
+	}
+	l.Close()
+
+	got := buf.String()
+	if strings.Count(got, "[INFO] queued") != 1 || !strings.Contains(got, "last message repeated 99 times") {
+		t.Errorf("Unexpected async output %q", got)
+	}
+}
+
+// BenchmarkDuplicateSuppression measures a suppressed repetition
+func BenchmarkDuplicateSuppression(b *testing.B) {
+	l := New(LoggerConfig{Level: core.INFO, Output: &bytes.Buffer{}, DuplicateWindow: time.Hour})
+	defer l.Close()
+	msg := []byte("repeated")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.InfoByte(msg)
+	}
+}
@@ -0,0 +1,281 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// Event is a log entry under construction, created by Logger.InfoEvent and friends.
+// Fields are written straight into a pooled core.LogEntry, so a chain like
+//
+//	l.InfoEvent().Str("user", u).Int("n", 3).Err(err).Msg("done")
+//
+// does not allocate. When the level is disabled the methods return a nil *Event,
+// on which every method is a no-op.
+//
+// An event must be finished with Msg, Msgf or Send, and must not be used afterwards.
+type Event struct {
+	logger *Logger
+	entry  *core.LogEntry
+}
+
+// eventPool reuses Event objects between log calls
+var eventPool = sync.Pool{
+	New: func() interface{} {
+		return &Event{}
+	},
+}
+
+// Event starts a new event at the given level, or returns nil if the level is disabled
+func (l *Logger) Event(level core.Level) *Event {
+	return l.newEvent(context.Background(), level)
+}
+
+// EventC starts a new context-aware event at the given level, or returns nil if the level is disabled
+func (l *Logger) EventC(ctx context.Context, level core.Level) *Event {
+	return l.newEvent(ctx, level)
+}
+
+// TraceEvent starts a new event with TRACE level
+func (l *Logger) TraceEvent() *Event { return l.newEvent(context.Background(), core.TRACE) }
+
+// DebugEvent starts a new event with DEBUG level
+func (l *Logger) DebugEvent() *Event { return l.newEvent(context.Background(), core.DEBUG) }
+
+// InfoEvent starts a new event with INFO level
+func (l *Logger) InfoEvent() *Event { return l.newEvent(context.Background(), core.INFO) }
+
+// NoticeEvent starts a new event with NOTICE level
+func (l *Logger) NoticeEvent() *Event { return l.newEvent(context.Background(), core.NOTICE) }
+
+// WarnEvent starts a new event with WARN level
+func (l *Logger) WarnEvent() *Event { return l.newEvent(context.Background(), core.WARN) }
+
+// ErrorEvent starts a new event with ERROR level
+func (l *Logger) ErrorEvent() *Event { return l.newEvent(context.Background(), core.ERROR) }
+
+// FatalEvent starts a new event with FATAL level
+func (l *Logger) FatalEvent() *Event { return l.newEvent(context.Background(), core.FATAL) }
+
+// PanicEvent starts a new event with PANIC level
+func (l *Logger) PanicEvent() *Event { return l.newEvent(context.Background(), core.PANIC) }
+
+// newEvent runs the same checks as the other logging methods and builds the entry
+func (l *Logger) newEvent(ctx context.Context, level core.Level) *Event {
+	l, ctxFields := l.resolveContextLogger(ctx, nil)
+
+	if l.closed.Load() {
+		return nil
+	}
+	if !l.levelEnabled(level, 0, logCallerSkip) {
+		return nil
+	}
+	if l.sampledOut() {
+		return nil
+	}
+
+	e := eventPool.Get().(*Event)
+	e.logger = l
+	e.entry = l.buildEntryByte(ctx, level, nil, nil, ctxFields)
+	e.entry.PC = l.samplerPC(0, logCallerSkip)
+	return e
+}
+
+// Enabled reports whether the event will be written
+func (e *Event) Enabled() bool {
+	return e != nil
+}
+
+// Field adds a typed field to the event
+func (e *Event) Field(f core.Field) *Event {
+	if e == nil {
+		return e
+	}
+	addTypedField(e.entry, f)
+	return e
+}
+
+// Fields adds typed fields to the event
+func (e *Event) Fields(fields ...core.Field) *Event {
+	if e == nil {
+		return e
+	}
+	for i := range fields {
+		addTypedField(e.entry, fields[i])
+	}
+	return e
+}
+
+// Str adds a string field to the event
+func (e *Event) Str(key, value string) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.String(key, value))
+	return e
+}
+
+// Bytes adds a byte slice field to the event, rendered as a string
+func (e *Event) Bytes(key string, value []byte) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.ByteString(key, value))
+	return e
+}
+
+// Int adds an integer field to the event
+func (e *Event) Int(key string, value int) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Int(key, value))
+	return e
+}
+
+// Int64 adds a 64-bit integer field to the event
+func (e *Event) Int64(key string, value int64) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Int64(key, value))
+	return e
+}
+
+// Uint64 adds an unsigned integer field to the event
+func (e *Event) Uint64(key string, value uint64) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Uint64(key, value))
+	return e
+}
+
+// Float64 adds a floating point field to the event
+func (e *Event) Float64(key string, value float64) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Float64(key, value))
+	return e
+}
+
+// Bool adds a boolean field to the event
+func (e *Event) Bool(key string, value bool) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Bool(key, value))
+	return e
+}
+
+// Dur adds a duration field to the event
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Duration(key, value))
+	return e
+}
+
+// Time adds a timestamp field to the event
+func (e *Event) Time(key string, value time.Time) *Event {
+	if e == nil {
+		return e
+	}
+	putTypedField(e.entry, core.Time(key, value))
+	return e
+}
+
+// Any adds a field of arbitrary type to the event
+func (e *Event) Any(key string, value interface{}) *Event {
+	if e == nil {
+		return e
+	}
+	addTypedField(e.entry, core.Any(key, value))
+	return e
+}
+
+// Err sets the error of the entry. A nil error is ignored.
+func (e *Event) Err(err error) *Event {
+	if e == nil || err == nil {
+		return e
+	}
+	e.entry.Error = err
+	return e
+}
+
+// AnErr adds an error as a field with the given key
+func (e *Event) AnErr(key string, err error) *Event {
+	if e == nil || err == nil {
+		return e
+	}
+	putTypedField(e.entry, core.NamedErr(key, err))
+	return e
+}
+
+// Elapsed sets the operation duration of the entry
+func (e *Event) Elapsed(d time.Duration) *Event {
+	if e == nil {
+		return e
+	}
+	e.entry.Duration = d
+	return e
+}
+
+// Tags adds tags to the entry
+func (e *Event) Tags(tags ...string) *Event {
+	if e == nil {
+		return e
+	}
+	for _, tag := range tags {
+		e.entry.Tags = append(e.entry.Tags, core.StringToBytes(tag))
+	}
+	return e
+}
+
+// Metric adds a named metric to the entry
+func (e *Event) Metric(name string, value float64) *Event {
+	if e == nil {
+		return e
+	}
+	e.entry.CustomMetrics[name] = value
+	return e
+}
+
+// Msg writes the event with the given message
+func (e *Event) Msg(msg string) {
+	if e == nil {
+		return
+	}
+	e.entry.Message = core.StringToBytes(msg)
+	e.write()
+}
+
+// Msgf writes the event with a formatted message
+func (e *Event) Msgf(format string, args ...interface{}) {
+	if e == nil {
+		return
+	}
+	e.entry.Message = e.logger.formatfArgsToBytes(format, args...)
+	e.write()
+}
+
+// Send writes the event without a message
+func (e *Event) Send() {
+	if e == nil {
+		return
+	}
+	e.write()
+}
+
+// write hands the entry to the async logger or writes it, then recycles the event
+func (e *Event) write() {
+	l, entry := e.logger, e.entry
+	e.logger, e.entry = nil, nil
+	eventPool.Put(e)
+	l.dispatchEntry(entry)
+}
@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// newEventTestLogger creates a JSON logger writing to w
+func newEventTestLogger(w io.Writer) *Logger {
+	return New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    w,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+}
+
+// TestEventFields tests that chained fields end up in the entry
+func TestEventFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := newEventTestLogger(&buf)
+	defer l.Close()
+
+	l.InfoEvent().
+		Str("user", "alice").
+		Int("n", 3).
+		Bool("ok", true).
+		Float64("ratio", 0.5).
+		Dur("wait", 2*time.Millisecond).
+		AnErr("cause", errors.New("timeout")).
+		Err(errors.New("boom")).
+		Tags("api").
+		Msg("done")
+
+	output := buf.String()
+	for _, want := range []string{
+		`"message":"done"`, `"user":"alice"`, `"n":3`, `"ok":true`, `"ratio":0.5`,
+		`"wait":2000000`, `"cause":"timeout"`, `"error":"boom"`, `"tags":["api"]`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in output %s", want, output)
+		}
+	}
+}
+
+// TestEventDisabled tests that a disabled event is nil and safe to chain
+func TestEventDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	l := newEventTestLogger(&buf)
+	defer l.Close()
+
+	e := l.DebugEvent()
+	if e != nil || e.Enabled() {
+		t.Fatal("Expected a nil event for a disabled level")
+	}
+	e.Str("k", "v").Int("n", 1).Err(errors.New("x")).Metric("m", 1).Msg("hidden")
+	e.Send()
+
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output, got %q", buf.String())
+	}
+}
+
+// TestEventSendAndMsgf tests the other finishers and the logger defaults
+func TestEventSendAndMsgf(t *testing.T) {
+	var buf bytes.Buffer
+	l := newEventTestLogger(&buf).Named("api").With(core.String("region", "eu"))
+	defer l.Close()
+
+	l.WarnEvent().Int("status", 503).Send()
+	l.EventC(context.Background(), core.ERROR).Msgf("retry %d", 2)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"logger":"api"`) || !strings.Contains(line, `"region":"eu"`) {
+			t.Errorf("Expected logger name and fields in %s", line)
+		}
+	}
+	if !strings.Contains(lines[0], `"status":503`) {
+		t.Errorf("Expected status field in %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"message":"retry 2"`) {
+		t.Errorf("Expected formatted message in %s", lines[1])
+	}
+}
+
+// TestEventAsync tests events handed to the async logger
+func TestEventAsync(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{
+		Level:                       core.INFO,
+		Output:                      &buf,
+		Formatter:                   formatter.NewJSONFormatter(),
+		AsyncLogging:                true,
+		AsyncWorkerCount:            1,
+		AsyncLogChannelBufferSize:   10,
+		LogProcessTimeout:           time.Second,
+		DisablePerLogContextTimeout: true,
+	})
+
+	l.InfoEvent().Uint64("n", 7).Msg("async event")
+	l.Close()
+
+	if !strings.Contains(buf.String(), `"n":7`) {
+		t.Errorf("Expected event in async output, got %q", buf.String())
+	}
+}
+
+// TestEventAllocations tests that a synchronous event does not allocate
+func TestEventAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not measured under the race detector")
+	}
+	l := New(LoggerConfig{
+		Level:  core.INFO,
+		Output: io.Discard,
+		Formatter: &formatter.TextFormatter{
+			ShowTimestamp: false,
+			ShowCaller:    false,
+		},
+	})
+	defer l.Close()
+	err := errors.New("boom")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		l.InfoEvent().Str("user", "alice").Int("n", 3).Err(err).Msg("done")
+	})
+	if allocs > 0 {
+		t.Errorf("Expected no allocations, got %v", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(100, func() {
+		l.DebugEvent().Str("user", "alice").Msg("filtered")
+	})
+	if allocs > 0 {
+		t.Errorf("Expected no allocations for a disabled event, got %v", allocs)
+	}
+}
+
+// BenchmarkEvent measures a synchronous event with a few fields
+func BenchmarkEvent(b *testing.B) {
+	l := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    io.Discard,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+	defer l.Close()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.InfoEvent().Str("user", "alice").Int("n", 3).Msg("done")
+	}
+}
@@ -0,0 +1,561 @@
+package logger
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/config"
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/hook"
+	"github.com/Lunar-Chipter/mire/sampler"
+)
+
+// envPrefix is the prefix of the environment variables read by LoadConfig
+const envPrefix = "MIRE"
+
+// FileConfig is the declarative form of LoggerConfig, read from JSON by LoadConfig.
+// Keys are snake_case; unset keys keep the defaults of New. Outputs are "stdout",
+// "stderr", "discard" or a file path, which is opened for appending.
+type FileConfig struct {
+	Level               string             `json:"level"`        // Minimum level, defaults to "info"
+	LevelRules          []LevelRuleConfig  `json:"level_rules"`  // Level overrides, see LevelRule
+	Formatter           FormatterConfig    `json:"formatter"`    // Defaults to the text formatter
+	Output              string             `json:"output"`       // Defaults to "stdout"
+	ErrorOutput         string             `json:"error_output"` // Defaults to "stderr"
+	ShowCaller          bool               `json:"show_caller"`
+	CallerDepth         int                `json:"caller_depth"`
+	EnableStackTrace    bool               `json:"enable_stack_trace"`
+	StackTraceDepth     int                `json:"stack_trace_depth"`
+	TimestampFormat     string             `json:"timestamp_format"`
+	Hostname            string             `json:"hostname"`
+	Application         string             `json:"application"`
+	Version             string             `json:"version"`
+	Environment         string             `json:"environment"`
+	MaxFieldSize        int                `json:"max_field_size"`
+	MaxMessageSize      int                `json:"max_message_size"`
+	UseContextLogger    bool               `json:"use_context_logger"`
+	BufferSize          int                `json:"buffer_size"`
+	FlushInterval       Duration           `json:"flush_interval"`
+	BatchSize           int                `json:"batch_size"`
+	BatchTimeout        Duration           `json:"batch_timeout"`
+	ClockInterval       Duration           `json:"clock_interval"`
+	Sampling            SamplingFileConfig `json:"sampling"`
+	Rotation            RotationFileConfig `json:"rotation"`
+	Async               AsyncFileConfig    `json:"async"`
+	ExitFlushTimeout    Duration           `json:"exit_flush_timeout"`
+	PanicOnPanicLevel   bool               `json:"panic_on_panic_level"`
+	EnableErrorFileHook bool               `json:"enable_error_file_hook"`
+	MaskStringValue     string             `json:"mask_string_value"`
+	DuplicateWindow     Duration           `json:"duplicate_window"`
+	Hooks               []HookConfig       `json:"hooks"` // Hooks created through the hook registry, see hook.Register
+	Sinks               []SinkFileConfig   `json:"sinks"` // When set, output, formatter, buffering and rotation are not used
+}
+
+// LevelRuleConfig is the declarative form of LevelRule
+type LevelRuleConfig struct {
+	Package   string `json:"package"`
+	Function  string `json:"function"`
+	File      string `json:"file"`
+	Component string `json:"component"`
+	Level     string `json:"level"`
+}
+
+// SamplingFileConfig configures log sampling. A positive tick enables a
+// sampler.TickSampler and a probability below 1 a sampler.HashSampler, in addition
+// to the rate.
+type SamplingFileConfig struct {
+	Enabled     bool     `json:"enabled"`
+	Rate        int      `json:"rate"`        // Log every Nth message
+	Tick        Duration `json:"tick"`        // Interval of the tick sampler
+	First       int      `json:"first"`       // Entries per level and message written in each tick
+	Thereafter  int      `json:"thereafter"`  // Then every Nth entry is written
+	Exempt      []string `json:"exempt"`      // Levels the tick and hash samplers never drop
+	Probability float64  `json:"probability"` // Fraction of traces kept by the hash sampler
+	HashField   string   `json:"hash_field"`  // Field hashed instead of the trace ID
+}
+
+// build creates the tick and hash samplers, or returns nil if sampling or both of
+// them are not enabled
+func (sc SamplingFileConfig) build() (sampler.Sampler, error) {
+	if !sc.Enabled {
+		return nil, nil
+	}
+	var exempt []core.Level
+	for _, name := range sc.Exempt {
+		level, err := core.ParseLevel(name)
+		if err != nil {
+			return nil, newErrorf("sampling exempt level: %v", err)
+		}
+		exempt = append(exempt, level)
+	}
+	if sc.Probability < 0 || sc.Probability > 1 {
+		return nil, newErrorf("sampling probability %v is not between 0 and 1", sc.Probability)
+	}
+
+	var samplers []sampler.Sampler
+	if sc.Probability > 0 && sc.Probability < 1 {
+		samplers = append(samplers, sampler.NewHashSampler(sampler.HashSamplerConfig{
+			Probability: sc.Probability,
+			Field:       sc.HashField,
+			Exempt:      exempt,
+		}))
+	}
+	if sc.Tick > 0 {
+		samplers = append(samplers, sampler.NewTickSampler(sampler.TickSamplerConfig{
+			Tick:       time.Duration(sc.Tick),
+			First:      sc.First,
+			Thereafter: sc.Thereafter,
+			Exempt:     exempt,
+		}))
+	}
+	switch len(samplers) {
+	case 0:
+		return nil, nil
+	case 1:
+		return samplers[0], nil
+	}
+	return sampler.Chain(samplers...), nil
+}
+
+// RotationFileConfig configures rotation of a file output
+type RotationFileConfig struct {
+	Enabled         bool     `json:"enabled"`
+	MaxSize         int64    `json:"max_size"` // Bytes
+	MaxAge          Duration `json:"max_age"`
+	MaxBackups      int      `json:"max_backups"`
+	LocalTime       bool     `json:"local_time"`
+	Compress        bool     `json:"compress"`
+	RotationTime    Duration `json:"rotation_time"`
+	FilenamePattern string   `json:"filename_pattern"`
+}
+
+// AsyncFileConfig configures asynchronous logging
+type AsyncFileConfig struct {
+	Enabled                     bool     `json:"enabled"`
+	Workers                     int      `json:"workers"`
+	ChannelBufferSize           int      `json:"channel_buffer_size"`
+	ProcessTimeout              Duration `json:"process_timeout"`
+	DisablePerLogContextTimeout bool     `json:"disable_per_log_context_timeout"`
+}
+
+// SinkFileConfig is the declarative form of SinkConfig
+type SinkFileConfig struct {
+	Name            string          `json:"name"`
+	Output          string          `json:"output"`
+	Formatter       FormatterConfig `json:"formatter"`
+	Level           string          `json:"level"` // Defaults to every level the logger passes
+	Loggers         []string        `json:"loggers"`
+	Synchronous     bool            `json:"synchronous"`
+	BufferSize      int             `json:"buffer_size"`
+	FlushInterval   Duration        `json:"flush_interval"`
+	BatchSize       int             `json:"batch_size"`
+	BatchTimeout    Duration        `json:"batch_timeout"`
+	MaskStringValue string          `json:"mask_string_value"`
+}
+
+// FormatterConfig selects a registered formatter, see formatter.Register.
+// In JSON it is either the formatter name or an object with the name and options:
+//
+//	"formatter": {"type": "text", "options": {"EnableColors": true, "ShowTimestamp": true}}
+//
+// Options are decoded into the formatter created by the factory, so their keys
+// are the formatter's field names.
+type FormatterConfig struct {
+	Type    string          `json:"type"`
+	Options json.RawMessage `json:"options"`
+}
+
+// UnmarshalJSON accepts a formatter name or an object with type and options
+func (fc *FormatterConfig) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &fc.Type)
+	}
+	type plain FormatterConfig
+	return json.Unmarshal(data, (*plain)(fc))
+}
+
+// UnmarshalText sets the formatter name, which lets MIRE_FORMATTER switch formatters.
+// Options of a different formatter are dropped.
+func (fc *FormatterConfig) UnmarshalText(text []byte) error {
+	if string(text) != fc.Type {
+		fc.Options = nil
+	}
+	fc.Type = string(text)
+	return nil
+}
+
+// build creates the formatter. It returns nil when neither a type nor options are
+// set, leaving the choice to the defaults of New.
+func (fc FormatterConfig) build() (formatter.Formatter, error) {
+	name := fc.Type
+	if name == "" {
+		if len(fc.Options) == 0 {
+			return nil, nil
+		}
+		name = "text"
+	}
+	factory, ok := formatter.Lookup(name)
+	if !ok {
+		return nil, newErrorf("unknown formatter %s, registered formatters are %s", name, strings.Join(formatter.Names(), ", "))
+	}
+	f := factory()
+	if len(fc.Options) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(fc.Options))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(f); err != nil {
+			return nil, newErrorf("invalid options for formatter %s: %v", name, err)
+		}
+	}
+	return f, nil
+}
+
+// HookConfig selects a registered hook, see hook.Register. In JSON it is either the
+// hook name or an object with the name and the options passed to the hook factory:
+//
+//	"hooks": [{"type": "file", "options": {"path": "errors.log"}}]
+type HookConfig struct {
+	Type    string          `json:"type"`
+	Options json.RawMessage `json:"options"`
+}
+
+// UnmarshalJSON accepts a hook name or an object with type and options
+func (hc *HookConfig) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &hc.Type)
+	}
+	type plain HookConfig
+	return json.Unmarshal(data, (*plain)(hc))
+}
+
+// build creates the hook through its registered factory
+func (hc HookConfig) build() (hook.Hook, error) {
+	factory, ok := hook.Lookup(hc.Type)
+	if !ok {
+		return nil, newErrorf("unknown hook %s, registered hooks are %s", hc.Type, strings.Join(hook.Names(), ", "))
+	}
+	h, err := factory(hc.Options)
+	if err != nil {
+		return nil, newErrorf("invalid hook %s: %v", hc.Type, err)
+	}
+	return h, nil
+}
+
+// Duration is a time.Duration read from a string such as "1.5s" or from a number of nanoseconds
+type Duration time.Duration
+
+// UnmarshalJSON accepts a duration string or a number of nanoseconds
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return d.UnmarshalText([]byte(s))
+	}
+	var n int64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return newErrorf("invalid duration %s", string(data))
+	}
+	*d = Duration(n)
+	return nil
+}
+
+// UnmarshalText parses a duration string such as "1.5s"
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// ParseFileConfig decodes a JSON config document. Unknown keys are rejected so
+// that typos do not go unnoticed.
+func ParseFileConfig(data []byte) (*FileConfig, error) {
+	fc := &FileConfig{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(fc); err != nil {
+		return nil, newErrorf("invalid logger config: %v", err)
+	}
+	return fc, nil
+}
+
+// LoadConfig reads a JSON config file, applies MIRE_* environment overrides and
+// builds a LoggerConfig. An empty path falls back to $MIRE_CONFIG; without either,
+// only the environment and the defaults are used.
+func LoadConfig(path string) (LoggerConfig, error) {
+	if path == "" {
+		path = os.Getenv(envPrefix + "_CONFIG")
+	}
+	fc := &FileConfig{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return LoggerConfig{}, err
+		}
+		if fc, err = ParseFileConfig(data); err != nil {
+			return LoggerConfig{}, err
+		}
+	}
+	if err := fc.ApplyEnv(os.LookupEnv); err != nil {
+		return LoggerConfig{}, err
+	}
+	return fc.Build()
+}
+
+// ApplyEnv overrides settings with variables named after their JSON keys, e.g.
+// MIRE_LEVEL, MIRE_FORMATTER, MIRE_ASYNC_WORKERS or MIRE_ROTATION_MAX_SIZE.
+// Lists such as sinks and level rules can only be set in the document.
+func (fc *FileConfig) ApplyEnv(lookup func(string) (string, bool)) error {
+	return applyEnv(reflect.ValueOf(fc).Elem(), envPrefix, lookup)
+}
+
+// applyEnv sets the scalar fields of the struct v from the variables prefix_<JSON key>
+func applyEnv(v reflect.Value, prefix string, lookup func(string) (string, bool)) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		key := prefix + "_" + strings.ToUpper(name)
+		field := v.Field(i)
+
+		if u, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if s, ok := lookup(key); ok {
+				if err := u.UnmarshalText([]byte(s)); err != nil {
+					return newErrorf("invalid %s: %v", key, err)
+				}
+			}
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field, key, lookup); err != nil {
+				return err
+			}
+			continue
+		}
+		s, ok := lookup(key)
+		if !ok {
+			continue
+		}
+		if err := setFromString(field, s); err != nil {
+			return newErrorf("invalid %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+// setFromString parses s into a string, bool or numeric field. Other kinds are left unchanged.
+func setFromString(field reflect.Value, s string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	}
+	return nil
+}
+
+// configBuilder tracks the files and hooks opened while building a LoggerConfig
+// so they can be closed if building fails
+type configBuilder struct {
+	reload bool        // Skip the outputs, which Logger.Reload does not change
+	opened []io.Closer // Files and hooks to close if building fails
+	files  []*os.File  // Opened outputs, handed to the logger on success
+}
+
+// Build creates the LoggerConfig described by the file config, opening file outputs and hooks.
+// The logger created from the config owns the opened files and closes them in Close.
+func (fc *FileConfig) Build() (LoggerConfig, error) {
+	return (&configBuilder{}).run(fc)
+}
+
+// buildReload creates the LoggerConfig for Logger.Reload. Outputs are not opened.
+func (fc *FileConfig) buildReload() (LoggerConfig, error) {
+	return (&configBuilder{reload: true}).run(fc)
+}
+
+// run builds the config, closing everything it opened if building fails
+func (b *configBuilder) run(fc *FileConfig) (LoggerConfig, error) {
+	c, err := b.build(fc)
+	if err != nil {
+		for _, closer := range b.opened {
+			closer.Close()
+		}
+		return LoggerConfig{}, err
+	}
+	c.files = b.files
+	return c, nil
+}
+
+func (b *configBuilder) build(fc *FileConfig) (LoggerConfig, error) {
+	c := LoggerConfig{
+		ShowCaller:                  fc.ShowCaller,
+		CallerDepth:                 fc.CallerDepth,
+		EnableStackTrace:            fc.EnableStackTrace,
+		StackTraceDepth:             fc.StackTraceDepth,
+		TimestampFormat:             fc.TimestampFormat,
+		Hostname:                    fc.Hostname,
+		Application:                 fc.Application,
+		Version:                     fc.Version,
+		Environment:                 fc.Environment,
+		MaxFieldSize:                fc.MaxFieldSize,
+		MaxMessageSize:              fc.MaxMessageSize,
+		UseContextLogger:            fc.UseContextLogger,
+		BufferSize:                  fc.BufferSize,
+		FlushInterval:               time.Duration(fc.FlushInterval),
+		BatchSize:                   fc.BatchSize,
+		BatchTimeout:                time.Duration(fc.BatchTimeout),
+		ClockInterval:               time.Duration(fc.ClockInterval),
+		EnableSampling:              fc.Sampling.Enabled,
+		SamplingRate:                fc.Sampling.Rate,
+		AsyncLogging:                fc.Async.Enabled,
+		AsyncWorkerCount:            fc.Async.Workers,
+		AsyncLogChannelBufferSize:   fc.Async.ChannelBufferSize,
+		LogProcessTimeout:           time.Duration(fc.Async.ProcessTimeout),
+		DisablePerLogContextTimeout: fc.Async.DisablePerLogContextTimeout,
+		ExitFlushTimeout:            time.Duration(fc.ExitFlushTimeout),
+		PanicOnPanicLevel:           fc.PanicOnPanicLevel,
+		EnableErrorFileHook:         fc.EnableErrorFileHook,
+		MaskStringValue:             fc.MaskStringValue,
+		DuplicateWindow:             time.Duration(fc.DuplicateWindow),
+	}
+
+	var err error
+	if c.Level, err = parseConfigLevel(fc.Level, core.INFO); err != nil {
+		return c, err
+	}
+	for i, r := range fc.LevelRules {
+		rule := LevelRule{Package: r.Package, Function: r.Function, File: r.File, Component: r.Component}
+		if rule.Level, err = parseConfigLevel(r.Level, core.INFO); err != nil {
+			return c, newErrorf("level rule %d: %v", i, err)
+		}
+		c.LevelRules = append(c.LevelRules, rule)
+	}
+	if c.Sampler, err = fc.Sampling.build(); err != nil {
+		return c, err
+	}
+	if c.Formatter, err = fc.Formatter.build(); err != nil {
+		return c, err
+	}
+	if c.Output, err = b.open(fc.Output, os.Stdout); err != nil {
+		return c, err
+	}
+	if c.ErrorOutput, err = b.open(fc.ErrorOutput, os.Stderr); err != nil {
+		return c, err
+	}
+
+	if r := fc.Rotation; r.Enabled && !b.reload {
+		if _, ok := c.Output.(*os.File); !ok || c.Output == os.Stdout || c.Output == os.Stderr {
+			return c, newErrorf("rotation requires a file output")
+		}
+		c.EnableRotation = true
+		c.RotationConfig = &config.RotationConfig{
+			MaxSize:         r.MaxSize,
+			MaxAge:          time.Duration(r.MaxAge),
+			MaxBackups:      r.MaxBackups,
+			LocalTime:       r.LocalTime,
+			Compress:        r.Compress,
+			RotationTime:    time.Duration(r.RotationTime),
+			FilenamePattern: r.FilenamePattern,
+		}
+	}
+
+	for _, hc := range fc.Hooks {
+		h, err := hc.build()
+		if err != nil {
+			return c, err
+		}
+		b.opened = append(b.opened, h)
+		c.Hooks = append(c.Hooks, h)
+	}
+
+	for i, s := range fc.Sinks {
+		sc := SinkConfig{
+			Name:            s.Name,
+			Loggers:         s.Loggers,
+			Synchronous:     s.Synchronous,
+			BufferSize:      s.BufferSize,
+			FlushInterval:   time.Duration(s.FlushInterval),
+			BatchSize:       s.BatchSize,
+			BatchTimeout:    time.Duration(s.BatchTimeout),
+			MaskStringValue: s.MaskStringValue,
+		}
+		if sc.Level, err = parseConfigLevel(s.Level, core.TRACE); err != nil {
+			return c, newErrorf("sink %d: %v", i, err)
+		}
+		if sc.Formatter, err = s.Formatter.build(); err != nil {
+			return c, newErrorf("sink %d: %v", i, err)
+		}
+		if sc.Output, err = b.open(s.Output, os.Stdout); err != nil {
+			return c, newErrorf("sink %d: %v", i, err)
+		}
+		c.Sinks = append(c.Sinks, sc)
+	}
+	return c, nil
+}
+
+// open resolves an output name to a writer, opening file paths for appending
+func (b *configBuilder) open(name string, def io.Writer) (io.Writer, error) {
+	if b.reload {
+		return def, nil
+	}
+	switch name {
+	case "":
+		return def, nil
+	case "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	case "discard":
+		return io.Discard, nil
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	b.opened = append(b.opened, f)
+	b.files = append(b.files, f)
+	return f, nil
+}
+
+// parseConfigLevel parses a level name, returning def for an empty name
+func parseConfigLevel(name string, def core.Level) (core.Level, error) {
+	if name == "" {
+		return def, nil
+	}
+	return core.ParseLevel(name)
+}
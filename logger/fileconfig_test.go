@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/hook"
+	"github.com/Lunar-Chipter/mire/sampler"
+)
+
+// envMap returns a lookup function backed by a map
+func envMap(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+}
+
+// TestParseFileConfig tests building a LoggerConfig from JSON
+func TestParseFileConfig(t *testing.T) {
+	dir := t.TempDir()
+	sinkPath := filepath.Join(dir, "app.json")
+	fc, err := ParseFileConfig([]byte(`{
+		"level": "debug",
+		"level_rules": [{"component": "db.*", "level": "warn"}],
+		"formatter": {"type": "text", "options": {"EnableColors": true, "ShowTimestamp": true}},
+		"output": "discard",
+		"flush_interval": "250ms",
+		"batch_timeout": 1000000,
+		"sampling": {"enabled": true, "rate": 10, "tick": "1s", "first": 5, "thereafter": 10, "exempt": ["error"]},
+		"async": {"enabled": true, "workers": 3, "process_timeout": "2s"},
+		"exit_flush_timeout": "1s",
+		"duplicate_window": "5s",
+		"sinks": [
+			{"name": "console", "output": "stdout", "formatter": "text", "level": "info", "synchronous": true},
+			{"name": "file", "output": "` + filepath.ToSlash(sinkPath) + `", "formatter": "json", "loggers": ["db.*"]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseFileConfig returned error: %v", err)
+	}
+	c, err := fc.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	if c.Level != core.DEBUG {
+		t.Errorf("Expected DEBUG, got %v", c.Level)
+	}
+	if len(c.LevelRules) != 1 || c.LevelRules[0].Component != "db.*" || c.LevelRules[0].Level != core.WARN {
+		t.Errorf("Unexpected level rules %+v", c.LevelRules)
+	}
+	tf, ok := c.Formatter.(*formatter.TextFormatter)
+	if !ok || !tf.EnableColors || !tf.ShowTimestamp {
+		t.Errorf("Expected text formatter with options, got %#v", c.Formatter)
+	}
+	if c.Output != io.Discard {
+		t.Errorf("Expected discard output, got %v", c.Output)
+	}
+	if c.FlushInterval != 250*time.Millisecond || c.BatchTimeout != time.Millisecond || c.ExitFlushTimeout != time.Second || c.DuplicateWindow != 5*time.Second {
+		t.Errorf("Unexpected durations %v %v %v %v", c.FlushInterval, c.BatchTimeout, c.ExitFlushTimeout, c.DuplicateWindow)
+	}
+	if !c.EnableSampling || c.SamplingRate != 10 {
+		t.Errorf("Unexpected sampling %v %d", c.EnableSampling, c.SamplingRate)
+	}
+	if _, ok := c.Sampler.(*sampler.TickSampler); !ok {
+		t.Errorf("Expected a tick sampler, got %#v", c.Sampler)
+	}
+	if !c.AsyncLogging || c.AsyncWorkerCount != 3 || c.LogProcessTimeout != 2*time.Second {
+		t.Errorf("Unexpected async settings %v %d %v", c.AsyncLogging, c.AsyncWorkerCount, c.LogProcessTimeout)
+	}
+
+	if len(c.Sinks) != 2 {
+		t.Fatalf("Expected 2 sinks, got %d", len(c.Sinks))
+	}
+	if s := c.Sinks[0]; s.Output != os.Stdout || s.Level != core.INFO || !s.Synchronous {
+		t.Errorf("Unexpected console sink %+v", s)
+	}
+	if _, ok := c.Sinks[0].Formatter.(*formatter.TextFormatter); !ok {
+		t.Errorf("Expected text formatter for the console sink, got %T", c.Sinks[0].Formatter)
+	}
+	file, ok := c.Sinks[1].Output.(*os.File)
+	if !ok || file.Name() != filepath.ToSlash(sinkPath) {
+		t.Fatalf("Expected file output, got %v", c.Sinks[1].Output)
+	}
+	defer file.Close()
+	if _, ok := c.Sinks[1].Formatter.(*formatter.JSONFormatter); !ok || c.Sinks[1].Level != core.TRACE {
+		t.Errorf("Unexpected file sink %+v", c.Sinks[1])
+	}
+}
+
+// TestFileConfigOutputsClosed tests that the logger closes the files opened by Build
+func TestFileConfigOutputsClosed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.ToSlash(filepath.Join(dir, "app.log"))
+	errPath := filepath.ToSlash(filepath.Join(dir, "errors.log"))
+	fc, err := ParseFileConfig([]byte(`{"output": "` + path + `", "error_output": "` + errPath + `", "rotation": {"enabled": true, "max_size": 1048576}}`))
+	if err != nil {
+		t.Fatalf("ParseFileConfig returned error: %v", err)
+	}
+	c, err := fc.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	output, errOutput := c.Output.(*os.File), c.ErrorOutput.(*os.File)
+
+	l := New(c)
+	if _, err := output.Write([]byte("x")); err == nil {
+		t.Error("Expected the output to be closed once the rotating writer took over its path")
+	}
+	l.Info("written")
+	l.Close()
+
+	if _, err := errOutput.Write([]byte("x")); err == nil {
+		t.Error("Expected the error output to be closed by Close")
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "written") {
+		t.Errorf("Expected the entry in %s, got %q", path, data)
+	}
+}
+
+// TestParseFileConfigErrors tests rejecting invalid documents
+func TestParseFileConfigErrors(t *testing.T) {
+	for name, doc := range map[string]string{
+		"unknown key":       `{"levle": "info"}`,
+		"bad duration":      `{"flush_interval": "soon"}`,
+		"bad level":         `{"level": "loud"}`,
+		"unknown formatter": `{"formatter": "xml"}`,
+		"bad option":        `{"formatter": {"type": "json", "options": {"Colors": true}}}`,
+		"rotation stdout":   `{"rotation": {"enabled": true}}`,
+		"sink level":        `{"sinks": [{"level": "loud"}]}`,
+		"unknown hook":      `{"hooks": ["webhook"]}`,
+		"hook options":      `{"hooks": [{"type": "file"}]}`,
+		"sampling exempt":   `{"sampling": {"enabled": true, "tick": "1s", "exempt": ["loud"]}}`,
+		"probability":       `{"sampling": {"enabled": true, "probability": 1.5}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			fc, err := ParseFileConfig([]byte(doc))
+			if err == nil {
+				_, err = fc.Build()
+			}
+			if err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+// TestParseFileConfigHooks tests creating hooks through the hook registry
+func TestParseFileConfigHooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.log")
+	fc, err := ParseFileConfig([]byte(`{"hooks": [{"type": "file", "options": {"path": "` + filepath.ToSlash(path) + `"}}]}`))
+	if err != nil {
+		t.Fatalf("ParseFileConfig returned error: %v", err)
+	}
+	c, err := fc.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if len(c.Hooks) != 1 {
+		t.Fatalf("Expected 1 hook, got %d", len(c.Hooks))
+	}
+	defer c.Hooks[0].Close()
+	if _, ok := c.Hooks[0].(*hook.SimpleFileHook); !ok {
+		t.Errorf("Expected *hook.SimpleFileHook, got %T", c.Hooks[0])
+	}
+}
+
+// TestFileConfigApplyEnv tests environment overrides
+func TestFileConfigApplyEnv(t *testing.T) {
+	fc, err := ParseFileConfig([]byte(`{"level": "info", "formatter": {"type": "text", "options": {"EnableColors": true}}, "async": {"workers": 2}}`))
+	if err != nil {
+		t.Fatalf("ParseFileConfig returned error: %v", err)
+	}
+	err = fc.ApplyEnv(envMap(map[string]string{
+		"MIRE_LEVEL":             "error",
+		"MIRE_FORMATTER":         "json",
+		"MIRE_ASYNC_ENABLED":     "true",
+		"MIRE_ASYNC_WORKERS":     "8",
+		"MIRE_FLUSH_INTERVAL":    "3s",
+		"MIRE_ROTATION_MAX_SIZE": "1048576",
+		"MIRE_APPLICATION":       "billing",
+	}))
+	if err != nil {
+		t.Fatalf("ApplyEnv returned error: %v", err)
+	}
+	c, err := fc.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	if c.Level != core.ERROR {
+		t.Errorf("Expected ERROR, got %v", c.Level)
+	}
+	if _, ok := c.Formatter.(*formatter.JSONFormatter); !ok {
+		t.Errorf("Expected the JSON formatter, got %T", c.Formatter)
+	}
+	if !c.AsyncLogging || c.AsyncWorkerCount != 8 {
+		t.Errorf("Unexpected async settings %v %d", c.AsyncLogging, c.AsyncWorkerCount)
+	}
+	if c.FlushInterval != 3*time.Second || c.Application != "billing" || fc.Rotation.MaxSize != 1<<20 {
+		t.Errorf("Unexpected overrides %v %q %d", c.FlushInterval, c.Application, fc.Rotation.MaxSize)
+	}
+
+	if err := fc.ApplyEnv(envMap(map[string]string{"MIRE_ASYNC_WORKERS": "many"})); err == nil || !strings.Contains(err.Error(), "MIRE_ASYNC_WORKERS") {
+		t.Errorf("Expected an error naming the variable, got %v", err)
+	}
+}
+
+// TestLoadConfig tests loading a file with environment overrides
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mire.json")
+	if err := os.WriteFile(path, []byte(`{"level": "warn", "output": "discard", "formatter": "json"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MIRE_CONFIG", path)
+	t.Setenv("MIRE_LEVEL", "debug")
+
+	c, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if c.Level != core.DEBUG || c.Output != io.Discard {
+		t.Errorf("Unexpected config level %v output %v", c.Level, c.Output)
+	}
+
+	l := New(c)
+	defer l.Close()
+	if l.GetLevel() != core.DEBUG {
+		t.Errorf("Expected level DEBUG, got %v", l.GetLevel())
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected a not-exist error, got %v", err)
+	}
+}
+
+// TestParseFileConfigSamplers tests combining the hash and tick samplers
+func TestParseFileConfigSamplers(t *testing.T) {
+	build := func(doc string) sampler.Sampler {
+		t.Helper()
+		fc, err := ParseFileConfig([]byte(doc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c, err := fc.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.Sampler
+	}
+
+	if _, ok := build(`{"sampling": {"enabled": true, "probability": 0.1, "hash_field": "request_id"}}`).(*sampler.HashSampler); !ok {
+		t.Error("Expected a hash sampler")
+	}
+	switch s := build(`{"sampling": {"enabled": true, "probability": 0.1, "tick": "1s"}}`).(type) {
+	case nil, *sampler.HashSampler, *sampler.TickSampler:
+		t.Errorf("Expected a chain of both samplers, got %#v", s)
+	}
+	if s := build(`{"sampling": {"enabled": true, "probability": 1, "rate": 5}}`); s != nil {
+		t.Errorf("Expected no sampler for probability 1, got %#v", s)
+	}
+}
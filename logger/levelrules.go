@@ -0,0 +1,307 @@
+package logger
+
+import (
+	"path"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// logCallerSkip is the number of frames between the level check in log/logFields
+// and the user code that called a logging method such as Info or InfoFields
+const logCallerSkip = 2
+
+// componentFieldKey is the field that names the component of a logger for level rules
+const componentFieldKey = "component"
+
+// LevelRule overrides the minimum level for log calls that match all of its
+// non-empty patterns. Patterns use path.Match syntax.
+//
+// Package is matched against the import path of the calling package and every
+// suffix of it that starts after a slash, so "payments/*" matches
+// "example.com/shop/payments/stripe". A pattern ending in "/..." matches the
+// package and all packages below it.
+// Function is matched against the function name without the package, e.g. "(*Server).Handle*".
+// File is matched against the file name, or against the path suffix when the pattern contains a slash.
+// Component is matched against the name of the logger, e.g. "db.*", see Logger.Component.
+type LevelRule struct {
+	Package   string     // Glob for the caller's package import path
+	Function  string     // Glob for the caller's function name
+	File      string     // Glob for the caller's source file
+	Component string     // Glob for the logger name or component
+	Level     core.Level // Minimum level for matching log calls
+}
+
+// levelRuleSet is an immutable compiled set of level rules with a per call site
+// cache. The cache is keyed by program counter only, so it is bounded by the number of
+// call sites; components are matched on every call. Changing the rules swaps in a new
+// set, which also drops the cache.
+type levelRuleSet struct {
+	rules         []LevelRule
+	minLevel      core.Level // Lowest level of any rule, used to reject calls without a lookup
+	maxLevel      core.Level // Highest level of any rule, used to accept calls without a lookup
+	usesCaller    bool       // Whether any rule needs the call site
+	usesComponent bool       // Whether any rule needs the logger component
+
+	mu    sync.RWMutex
+	cache map[uintptr]*levelRuleSite
+}
+
+// levelRuleSite is the cached result of matching the caller patterns of the rules
+// against one call site
+type levelRuleSite struct {
+	level      core.Level
+	matched    bool  // A rule without a component pattern matched, level applies
+	components []int // Rules before it that matched the call site but also need the component
+}
+
+// newLevelRuleSet validates and compiles level rules. It returns nil for an empty rule list.
+func newLevelRuleSet(rules []LevelRule) (*levelRuleSet, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	set := &levelRuleSet{
+		rules:    append([]LevelRule(nil), rules...),
+		minLevel: rules[0].Level,
+		maxLevel: rules[0].Level,
+		cache:    make(map[uintptr]*levelRuleSite),
+	}
+	for i, r := range set.rules {
+		if r.Package == "" && r.Function == "" && r.File == "" && r.Component == "" {
+			return nil, newErrorf("level rule %d has no pattern", i)
+		}
+		for _, pattern := range []string{strings.TrimSuffix(r.Package, "/..."), r.Function, r.File, r.Component} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, newErrorf("level rule %d has invalid pattern %s: %v", i, pattern, err)
+			}
+		}
+		if r.Level < set.minLevel {
+			set.minLevel = r.Level
+		}
+		if r.Level > set.maxLevel {
+			set.maxLevel = r.Level
+		}
+		if r.Package != "" || r.Function != "" || r.File != "" {
+			set.usesCaller = true
+		}
+		if r.Component != "" {
+			set.usesComponent = true
+		}
+	}
+	return set, nil
+}
+
+// SetLevelRules replaces the level rules of the logger at runtime. The first matching
+// rule decides the minimum level of a log call; calls that match no rule use the
+// logger level. The rules are shared by the logger and all loggers derived from it.
+// Calling SetLevelRules without rules removes all overrides.
+func (l *Logger) SetLevelRules(rules ...LevelRule) error {
+	set, err := newLevelRuleSet(rules)
+	if err != nil {
+		return err
+	}
+	l.levelRules.Store(set)
+	return nil
+}
+
+// LevelRules returns a copy of the current level rules
+func (l *Logger) LevelRules() []LevelRule {
+	set := l.levelRules.Load()
+	if set == nil {
+		return nil
+	}
+	return append([]LevelRule(nil), set.rules...)
+}
+
+// Component returns the component of the logger that level rules match against:
+// the logger name set with Named, or else the value of its "component" field
+func (l *Logger) Component() string {
+	if l.name != "" {
+		return l.name
+	}
+	if v, ok := l.fields[componentFieldKey]; ok {
+		return core.BytesToString(v)
+	}
+	for i := range l.typedFields {
+		if l.typedFields[i].Key == componentFieldKey && l.typedFields[i].Type == core.StringType {
+			return l.typedFields[i].String
+		}
+	}
+	return ""
+}
+
+// minEnabledLevel returns the lowest level that any call site of the logger can log at
+func (l *Logger) minEnabledLevel() core.Level {
+	global := l.GetLevel()
+	if set := l.levelRules.Load(); set != nil && set.minLevel < global {
+		return set.minLevel
+	}
+	return global
+}
+
+// levelEnabled reports whether a call at level passes the level rules and the logger level.
+// pc identifies the call site; when it is 0 the call site is looked up skip frames
+// above the function that called levelEnabled.
+func (l *Logger) levelEnabled(level core.Level, pc uintptr, skip int) bool {
+	global := l.GetLevel()
+	set := l.levelRules.Load()
+	if set == nil {
+		return level >= global
+	}
+
+	// Below or above every possible threshold: no need to find the call site
+	if level < set.minLevel && level < global {
+		return false
+	}
+	if level >= set.maxLevel && level >= global {
+		return true
+	}
+
+	if pc == 0 && set.usesCaller {
+		var pcs [1]uintptr
+		if runtime.Callers(skip+2+l.callerSkip, pcs[:]) > 0 {
+			pc = pcs[0]
+		}
+	}
+	var component string
+	if set.usesComponent {
+		component = l.Component()
+	}
+
+	if ruleLevel, ok := set.decide(pc, component); ok {
+		return level >= ruleLevel
+	}
+	return level >= global
+}
+
+// decide returns the level of the first rule that matches the call site and component
+func (s *levelRuleSet) decide(pc uintptr, component string) (core.Level, bool) {
+	site := s.site(pc)
+	for _, i := range site.components {
+		if matchGlob(s.rules[i].Component, component) {
+			return s.rules[i].Level, true
+		}
+	}
+	return site.level, site.matched
+}
+
+// site returns the cached match of a call site, matching the rules on a cache miss
+func (s *levelRuleSet) site(pc uintptr) *levelRuleSite {
+	s.mu.RLock()
+	site, ok := s.cache[pc]
+	s.mu.RUnlock()
+	if ok {
+		return site
+	}
+
+	var pkg, function, file string
+	if pc != 0 {
+		pkg, function, file = callSite(pc)
+	}
+	site = &levelRuleSite{}
+	for i := range s.rules {
+		r := &s.rules[i]
+		if !matchCaller(r, pkg, function, file) {
+			continue
+		}
+		if r.Component != "" {
+			site.components = append(site.components, i)
+			continue
+		}
+		site.level, site.matched = r.Level, true
+		break
+	}
+
+	s.mu.Lock()
+	s.cache[pc] = site
+	s.mu.Unlock()
+	return site
+}
+
+// callSite returns the package import path, function name and file of a program counter
+func callSite(pc uintptr) (pkg, function, file string) {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	file = frame.File
+
+	name := frame.Function
+	lastSlash := strings.LastIndexByte(name, '/')
+	if dot := strings.IndexByte(name[lastSlash+1:], '.'); dot >= 0 {
+		pkg = name[:lastSlash+1+dot]
+		function = name[lastSlash+1+dot+1:]
+	} else {
+		function = name
+	}
+	return pkg, function, file
+}
+
+// matchCaller reports whether the non-empty package, function and file patterns of
+// the rule match the call site
+func matchCaller(r *LevelRule, pkg, function, file string) bool {
+	if r.Package != "" && !matchPackage(r.Package, pkg) {
+		return false
+	}
+	if r.Function != "" && !matchGlob(r.Function, function) {
+		return false
+	}
+	if r.File != "" && !matchFile(r.File, file) {
+		return false
+	}
+	return true
+}
+
+// matchPackage matches a package pattern against an import path and its suffixes
+func matchPackage(pattern, pkg string) bool {
+	if pkg == "" {
+		return false
+	}
+	base, recursive := strings.CutSuffix(pattern, "/...")
+	for candidate := pkg; ; {
+		if matchGlob(base, candidate) {
+			return true
+		}
+		if recursive {
+			// "base/..." also matches every package below base
+			for i := 0; i < len(candidate); i++ {
+				if candidate[i] == '/' && matchGlob(base, candidate[:i]) {
+					return true
+				}
+			}
+		}
+
+		i := strings.IndexByte(candidate, '/')
+		if i < 0 {
+			return false
+		}
+		candidate = candidate[i+1:]
+	}
+}
+
+// matchFile matches a file pattern against the file name, or against path suffixes
+// when the pattern contains a slash
+func matchFile(pattern, file string) bool {
+	if file == "" {
+		return false
+	}
+	if !strings.Contains(pattern, "/") {
+		return matchGlob(pattern, file[strings.LastIndexByte(file, '/')+1:])
+	}
+	for candidate := file; ; {
+		if matchGlob(pattern, candidate) {
+			return true
+		}
+		i := strings.IndexByte(candidate, '/')
+		if i < 0 {
+			return false
+		}
+		candidate = candidate[i+1:]
+	}
+}
+
+// matchGlob matches a validated path.Match pattern
+func matchGlob(pattern, name string) bool {
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
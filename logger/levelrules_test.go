@@ -0,0 +1,237 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// newRuleTestLogger creates an INFO logger writing plain text to buf
+func newRuleTestLogger(buf *bytes.Buffer, rules ...LevelRule) *Logger {
+	return New(LoggerConfig{
+		Level:      core.INFO,
+		Output:     buf,
+		LevelRules: rules,
+		Formatter: &formatter.TextFormatter{
+			EnableColors:  false,
+			ShowTimestamp: false,
+			ShowCaller:    false,
+		},
+	})
+}
+
+// debugFromHelper logs from a dedicated function so function rules can target it
+func debugFromHelper(l *Logger, msg string) {
+	l.Debug(msg)
+}
+
+// TestLevelRulesCallSite tests package, function and file rules
+func TestLevelRulesCallSite(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       LevelRule
+		wantDirect bool
+		wantHelper bool
+	}{
+		{"package suffix", LevelRule{Package: "mire/logger", Level: core.DEBUG}, true, true},
+		{"package glob", LevelRule{Package: "mire/*", Level: core.DEBUG}, true, true},
+		{"package recursive", LevelRule{Package: "github.com/Lunar-Chipter/...", Level: core.DEBUG}, true, true},
+		{"other package", LevelRule{Package: "payments/*", Level: core.DEBUG}, false, false},
+		{"function", LevelRule{Function: "debugFrom*", Level: core.DEBUG}, false, true},
+		{"file", LevelRule{File: "levelrules_test.go", Level: core.DEBUG}, true, true},
+		{"file path", LevelRule{File: "logger/levelrules_*.go", Level: core.DEBUG}, true, true},
+		{"all patterns must match", LevelRule{Package: "mire/logger", Function: "nothing", Level: core.DEBUG}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := newRuleTestLogger(&buf, tt.rule)
+			defer l.Close()
+
+			l.Debug("direct")
+			debugFromHelper(l, "helper")
+
+			output := buf.String()
+			if got := strings.Contains(output, "direct"); got != tt.wantDirect {
+				t.Errorf("direct call logged = %v, want %v (output %q)", got, tt.wantDirect, output)
+			}
+			if got := strings.Contains(output, "helper"); got != tt.wantHelper {
+				t.Errorf("helper call logged = %v, want %v (output %q)", got, tt.wantHelper, output)
+			}
+		})
+	}
+}
+
+// TestLevelRulesComponent tests rules that lower and raise the level of a component
+func TestLevelRulesComponent(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRuleTestLogger(&buf,
+		LevelRule{Component: "payments", Level: core.DEBUG},
+		LevelRule{Component: "noisy*", Level: core.WARN},
+	)
+	defer l.Close()
+
+	payments := l.WithFields(map[string]interface{}{"component": "payments"})
+	noisy := l.With(core.String("component", "noisy-cache"))
+
+	payments.Debug("payments debug")
+	noisy.Info("noisy info")
+	noisy.Warn("noisy warn")
+	l.Debug("root debug")
+	l.Info("root info")
+
+	output := buf.String()
+	for _, want := range []string{"payments debug", "noisy warn", "root info"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output %q", want, output)
+		}
+	}
+	for _, unwanted := range []string{"noisy info", "root debug"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Did not expect %q in output %q", unwanted, output)
+		}
+	}
+	if payments.Component() != "payments" {
+		t.Errorf("Expected component payments, got %q", payments.Component())
+	}
+}
+
+// TestLevelRulesNamedLogger tests component rules against named loggers
+func TestLevelRulesNamedLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRuleTestLogger(&buf, LevelRule{Component: "db.*", Level: core.DEBUG})
+	defer l.Close()
+
+	db := l.Named("db")
+	db.Named("pool").Debug("pool debug")
+	db.Debug("db debug")
+
+	output := buf.String()
+	if !strings.Contains(output, "pool debug") {
+		t.Errorf("Expected db.pool debug in output %q", output)
+	}
+	if strings.Contains(output, "db debug") {
+		t.Errorf("db does not match db.*, got %q", output)
+	}
+}
+
+// TestLevelRulesCacheBounded tests that many components logging from one call site share one cache entry
+func TestLevelRulesCacheBounded(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRuleTestLogger(&buf, LevelRule{Component: "tenant-1?", Level: core.DEBUG})
+	defer l.Close()
+
+	for i := 0; i < 100; i++ {
+		l.Named("tenant-" + strconv.Itoa(i)).Debug("tenant debug ", i)
+	}
+
+	if got := strings.Count(buf.String(), "tenant debug"); got != 10 {
+		t.Errorf("Expected the 10 tenants matching the rule to log, got %d in %q", got, buf.String())
+	}
+	set := l.levelRules.Load()
+	set.mu.RLock()
+	defer set.mu.RUnlock()
+	if len(set.cache) != 1 {
+		t.Errorf("Expected one cached call site, got %d", len(set.cache))
+	}
+}
+
+// TestLevelRulesRuntimeChange tests replacing and clearing rules at runtime
+func TestLevelRulesRuntimeChange(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRuleTestLogger(&buf)
+	defer l.Close()
+	child := l.WithFields(map[string]interface{}{"k": "v"})
+
+	child.Debug("before")
+	if err := l.SetLevelRules(LevelRule{Package: "mire/logger", Level: core.DEBUG}); err != nil {
+		t.Fatalf("SetLevelRules returned error: %v", err)
+	}
+	child.Debug("during")
+	if len(child.LevelRules()) != 1 {
+		t.Errorf("Clone should share the rules, got %v", child.LevelRules())
+	}
+	if err := l.SetLevelRules(); err != nil {
+		t.Fatalf("SetLevelRules returned error: %v", err)
+	}
+	child.Debug("after")
+
+	output := buf.String()
+	if strings.Contains(output, "before") || strings.Contains(output, "after") {
+		t.Errorf("Debug should only be logged while the rule is set, got %q", output)
+	}
+	if !strings.Contains(output, "during") {
+		t.Errorf("Expected debug while the rule is set, got %q", output)
+	}
+}
+
+// TestLevelRulesInvalid tests rule validation
+func TestLevelRulesInvalid(t *testing.T) {
+	l := newRuleTestLogger(&bytes.Buffer{})
+	defer l.Close()
+
+	if err := l.SetLevelRules(LevelRule{Level: core.DEBUG}); err == nil {
+		t.Error("Expected an error for a rule without patterns")
+	}
+	if err := l.SetLevelRules(LevelRule{File: "[", Level: core.DEBUG}); err == nil {
+		t.Error("Expected an error for a malformed pattern")
+	}
+	if l.LevelRules() != nil {
+		t.Errorf("Invalid rules should not be applied, got %v", l.LevelRules())
+	}
+}
+
+// TestLevelRulesSlog tests that slog records are matched against their own call site
+func TestLevelRulesSlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := newRuleTestLogger(&buf, LevelRule{File: "levelrules_test.go", Level: core.DEBUG})
+	defer l.Close()
+
+	slogger := slog.New(NewSlogHandler(l))
+	slogger.Debug("slog debug")
+
+	if !strings.Contains(buf.String(), "slog debug") {
+		t.Errorf("Expected slog debug record in output, got %q", buf.String())
+	}
+}
+
+// TestMatchPackage tests package pattern matching against import paths
+func TestMatchPackage(t *testing.T) {
+	tests := []struct {
+		pattern string
+		pkg     string
+		want    bool
+	}{
+		{"payments", "example.com/shop/payments", true},
+		{"payments/*", "example.com/shop/payments/stripe", true},
+		{"payments/*", "example.com/shop/payments", false},
+		{"payments/...", "example.com/shop/payments", true},
+		{"payments/...", "example.com/shop/payments/stripe/v2", true},
+		{"shop/pay*", "example.com/shop/payments", true},
+		{"payments", "example.com/shop/mypayments", false},
+		{"payments", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchPackage(tt.pattern, tt.pkg); got != tt.want {
+			t.Errorf("matchPackage(%q, %q) = %v, want %v", tt.pattern, tt.pkg, got, tt.want)
+		}
+	}
+}
+
+// BenchmarkLevelRulesFiltered measures a filtered call when rules are set
+func BenchmarkLevelRulesFiltered(b *testing.B) {
+	l := newRuleTestLogger(&bytes.Buffer{}, LevelRule{Package: "payments/*", Level: core.DEBUG})
+	defer l.Close()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.DebugFields("filtered")
+	}
+}
@@ -3,7 +3,6 @@ package logger
 import (
 	"bytes"
 	"context"
-	"fmt"
 	"io"
 	"os"
 	"strconv"
@@ -25,6 +24,7 @@ const (
 	DEFAULT_CALLER_DEPTH     = 3
 	DEFAULT_BUFFER_SIZE      = 1000
 	DEFAULT_FLUSH_INTERVAL   = 5 * time.Second
+	DEFAULT_EXIT_FLUSH_TIMEOUT = 5 * time.Second
 	
 	// Buffer sizes dikonfigurasi saat inisialisasi - aligned with zero-allocation philosophy
 	SmallBufferSize          = 512   // Untuk perf-critical
@@ -35,7 +35,9 @@ const (
 
 // LoggerConfig holds configuration for the logger
 type LoggerConfig struct {
-	Level             core.Level                      // Minimum level to log
+	Level             core.Level                      // Initial minimum level to log, see Logger.SetLevel
+	LevelRules        []LevelRule                     // Initial level overrides, see Logger.SetLevelRules
+	UseContextLogger  bool                            // Make the *C methods log through the context's logger and fields, see IntoContext
 	EnableColors      bool                            // Enable ANSI colors in output
 	Output            io.Writer                       // Output writer for logs
 	ErrorOutput       io.Writer                       // Output writer for internal logger errors
@@ -53,6 +55,7 @@ type LoggerConfig struct {
 	StackTraceDepth   int                             // Maximum depth for stack trace
 	EnableSampling    bool                            // Enable log sampling
 	SamplingRate      int                             // Sampling rate (log every Nth message)
+	Sampler           sampler.Sampler                 // Decides for every built entry whether it is written, e.g. sampler.TickSampler; FATAL and PANIC entries are always written
 	BufferSize        int                             // Size of buffer for buffered writer
 	FlushInterval     time.Duration                   // Interval to flush buffered logs
 	EnableRotation    bool                            // Enable log rotation
@@ -68,6 +71,9 @@ type LoggerConfig struct {
 	ErrorHandler      func(error)                     // Function to handle internal logger errors
 	OnFatal           func(*core.LogEntry)            // Function to call when a fatal log occurs
 	OnPanic           func(*core.LogEntry)            // Function to call when a panic log occurs
+	OnConfigChange    func(ConfigEvent)               // Function to call when the level changes or the config is reloaded at runtime; without it the change goes to ErrorHandler or ErrorOutput
+	ExitFlushTimeout  time.Duration                   // Deadline for draining the async queue, flushing the buffer and closing hooks before exiting on FATAL/PANIC
+	PanicOnPanicLevel bool                            // Call panic() after logging at PANIC level instead of ExitFunc
 	Hooks             []hook.Hook                     // Hooks to execute for each log entry
 	EnableErrorFileHook bool                          // Enable built-in error file hook for ERROR+ levels
 	BatchSize         int                             // Size of batch for batched writes
@@ -83,6 +89,10 @@ type LoggerConfig struct {
 	DisablePerLogContextTimeout bool                  // Disable context timeout per log in async mode
 	ClockInterval time.Duration                   // Interval for clock (for timestamp optimization)
 	MaskStringValue   string                          // String value to use for masking sensitive data
+	Sinks             []SinkConfig                    // Fan out to several outputs; when set, Output, Formatter, buffering and rotation are not used
+	DuplicateWindow   time.Duration                   // Collapse identical consecutive messages (same level, message and call site) logged within the window into one summary line; 0 disables
+
+	files []*os.File // Outputs opened by FileConfig.Build, owned and closed by the logger
 }
 // validate ensures the logger configuration has sane defaults.
 func validate(c *LoggerConfig) {
@@ -93,30 +103,9 @@ func validate(c *LoggerConfig) {
 		c.ErrorOutput = os.Stderr
 	}
 	if c.Formatter == nil {
-		// Use default mask string for the default formatter
-		defaultFormatter := &formatter.TextFormatter{TimestampFormat: DEFAULT_TIMESTAMP_FORMAT}
-		if c.MaskStringValue == "" {
-			defaultFormatter.MaskStringBytes = []byte("[MASKED]") // Default mask
-		} else {
-			defaultFormatter.MaskStringBytes = []byte(c.MaskStringValue)
-		}
-		c.Formatter = defaultFormatter
-	} else {
-		// If a formatter is provided, check its type and set MaskStringBytes
-		if tf, ok := c.Formatter.(*formatter.TextFormatter); ok {
-			if c.MaskStringValue == "" {
-				tf.MaskStringBytes = []byte("[MASKED]") // Default mask
-			} else {
-				tf.MaskStringBytes = []byte(c.MaskStringValue)
-			}
-		} else if jf, ok := c.Formatter.(*formatter.JSONFormatter); ok {
-			if c.MaskStringValue == "" {
-				jf.MaskStringBytes = []byte("[MASKED]") // Default mask
-			} else {
-				jf.MaskStringBytes = []byte(c.MaskStringValue)
-			}
-		}
+		c.Formatter = &formatter.TextFormatter{TimestampFormat: DEFAULT_TIMESTAMP_FORMAT}
 	}
+	applyMask(c.Formatter, c.MaskStringValue)
 
 	if c.CallerDepth <= 0 {
 		c.CallerDepth = DEFAULT_CALLER_DEPTH
@@ -124,23 +113,53 @@ func validate(c *LoggerConfig) {
 	if c.FlushInterval <= 0 {
 		c.FlushInterval = DEFAULT_FLUSH_INTERVAL
 	}
+	if c.ExitFlushTimeout <= 0 {
+		c.ExitFlushTimeout = DEFAULT_EXIT_FLUSH_TIMEOUT
+	}
     if c.TimestampFormat == "" {
         c.TimestampFormat = DEFAULT_TIMESTAMP_FORMAT
     }
 }
+// applyMask sets the string used to mask sensitive fields on the built-in formatters.
+// An empty mask selects the default "[MASKED]".
+func applyMask(f formatter.Formatter, mask string) {
+	maskBytes := []byte("[MASKED]") // Default mask
+	if mask != "" {
+		maskBytes = []byte(mask)
+	}
+	if tf, ok := f.(*formatter.TextFormatter); ok {
+		tf.MaskStringBytes = maskBytes
+	} else if jf, ok := f.(*formatter.JSONFormatter); ok {
+		jf.MaskStringBytes = maskBytes
+	} else if lf, ok := f.(*formatter.LogfmtFormatter); ok {
+		lf.MaskStringBytes = maskBytes
+	}
+}
+
 // Logger is the main logging structure
 type Logger struct {
 	Config           LoggerConfig                    // Configuration for the logger
-	formatter        formatter.Formatter             // Formatter to use for log entries
+	formatter        *atomic.Pointer[formatterRef]   // Formatter for the output, replaceable at runtime (shared with clones)
 	out              io.Writer                       // Output writer for logs
 	errOut           io.Writer                       // Output writer for internal logger errors
-	errOutMu         sync.Mutex                      // Mutex for protecting errOut
+	errOutMu         *sync.Mutex                     // Mutex for protecting errOut (pointer so clones share it)
 	mu               *sync.RWMutex                   // Mutex for protecting internal state (changed to pointer to allow safe cloning)
-	hooks            []hook.Hook                     // Hooks to execute for each log entry
+	hooks            *[]hook.Hook                    // Hooks added with AddHook (shared with AddCallerSkip copies)
+	configHooks      *[]hook.Hook                    // Hooks from LoggerConfig.Hooks, replaceable at runtime (shared with clones, guarded by mu)
 	exitFunc         func(int)                       // Function to call on fatal/panic
 	fields           map[string][]byte               // Default fields to include in all logs as []byte for zero allocation
-	sampler          *sampler.SamplingLogger         // Sampler for log sampling
+	typedFields      []core.Field                    // Default typed fields to include in all logs
+	name             string                          // Dotted logger name built by Named, e.g. "db.pool"
+	callerSkip       int                             // Extra frames between the user code and the logging methods, see AddCallerSkip
+	err              error                           // Default error stored in LogEntry.Error
+	duration         time.Duration                   // Default duration stored in LogEntry.Duration
+	tags             [][]byte                        // Default tags stored in LogEntry.Tags
+	customMetrics    map[string]float64              // Default metrics stored in LogEntry.CustomMetrics
+	sampler          *atomic.Pointer[sampler.SamplingLogger] // Sampler for log sampling, nil when disabled (shared with clones)
+	entrySampler     *atomic.Pointer[samplerRef]     // Sampler of built entries, nil when disabled (shared with clones)
+	dedup            *deduplicator                   // Suppresses repeated messages, nil when disabled (shared with clones)
 	buffer           *writer.BufferedWriter          // Buffered writer for performance
+	sinks            []*sink                         // Outputs configured with LoggerConfig.Sinks (shared with clones)
 	rotation         *writer.RotatingFileWriter      // Rotating file writer for log rotation
 	contextExtractor func(context.Context) map[string][]byte // Function to extract fields from context
 	metrics          metric.MetricsCollector         // Metrics collector
@@ -149,7 +168,10 @@ type Logger struct {
 	stats            *LoggerStats                    // Statistics for the logger
 	asyncLogger      *writer.AsyncLogger             // Async logger for non-blocking logging
 	errorFileHook    *hook.SimpleFileHook            // Built-in error file hook for ERROR+ levels
-	closed           atomic.Bool                     // Flag to indicate if logger is closed
+	closed           *atomic.Bool                    // Flag to indicate if logger is closed (shared with clones)
+	hooksClosed      *atomic.Bool                    // Set once the hooks were closed by a FATAL or PANIC entry (shared with clones)
+	level            *atomic.Int32                   // Minimum level to log, adjustable at runtime (shared with clones)
+	levelRules       *atomic.Pointer[levelRuleSet]   // Per package/function/file/component level overrides (shared with clones)
 	pid              int                             // Process ID
 	clock            *util.Clock                 // Clock for timestamp optimization
 }
@@ -160,6 +182,7 @@ type LoggerStats struct {
 	BytesWritten int64
 	StartTime    time.Time
 	mu           sync.RWMutex
+	rates        sampler.RateReporter // Reports the keep ratio of an adaptive sampler, set by SetSampler
 }
 
 // NewLoggerStats creates a new LoggerStats
@@ -194,10 +217,39 @@ func (ls *LoggerStats) GetStats() map[string]interface{} {
 		counts[level.String()] = count
 	}
 	stats["log_counts"] = counts
+
+	if ls.rates != nil {
+		if rates := ls.rates.Rates(); rates != nil {
+			byName := make(map[string]float64, len(rates))
+			for level, rate := range rates {
+				byName[level.String()] = rate
+			}
+			stats["sampling_rates"] = byName
+		}
+	}
 	
 	return stats
 }
 
+// SamplingRates returns the fraction of entries the sampler currently keeps per
+// level, or nil if the sampler does not adapt its rate, see sampler.RateReporter
+func (ls *LoggerStats) SamplingRates() map[core.Level]float64 {
+	ls.mu.RLock()
+	rates := ls.rates
+	ls.mu.RUnlock()
+	if rates == nil {
+		return nil
+	}
+	return rates.Rates()
+}
+
+// setRates sets the reporter of the sampling rates, nil to remove it
+func (ls *LoggerStats) setRates(rates sampler.RateReporter) {
+	ls.mu.Lock()
+	ls.rates = rates
+	ls.mu.Unlock()
+}
+
 // NewDefaultLogger creates a logger with default configuration
 // This logger is configured with standard settings suitable for most applications
 func NewDefaultLogger() *Logger {
@@ -229,13 +281,22 @@ func New(config LoggerConfig) *Logger {
 
 	l := &Logger{
 		Config:           config,
-		formatter:        config.Formatter,
+		formatter:        new(atomic.Pointer[formatterRef]),
 		out:              config.Output,
 		errOut:           config.ErrorOutput,
+		errOutMu:         new(sync.Mutex),
 		mu:               new(sync.RWMutex), // Initialize the mutex pointer
+		closed:           new(atomic.Bool),
+		hooksClosed:      new(atomic.Bool),
+		level:            new(atomic.Int32),
+		levelRules:       new(atomic.Pointer[levelRuleSet]),
 		exitFunc:         config.ExitFunc,
 		fields:           make(map[string][]byte),
-		hooks:            config.Hooks, // Initialize hooks from config
+		configHooks:      &config.Hooks,
+		hooks:            new([]hook.Hook),
+		sampler:          new(atomic.Pointer[sampler.SamplingLogger]),
+		entrySampler:     new(atomic.Pointer[samplerRef]),
+		dedup:            newDeduplicator(config.DuplicateWindow),
 		contextExtractor: config.ContextExtractor,
 		metrics:          config.MetricsCollector,
 		onFatal:          config.OnFatal,
@@ -244,13 +305,19 @@ func New(config LoggerConfig) *Logger {
 		pid:              os.Getpid(),
 	}
 
+	l.formatter.Store(&formatterRef{config.Formatter})
+	l.level.Store(int32(config.Level))
+	if err := l.SetLevelRules(config.LevelRules...); err != nil {
+		l.handleError(newErrorf("invalid level rules: %v", err))
+	}
+
 	if config.EnableErrorFileHook {
 		errorHook, err := hook.NewFileHook("errors.log") // Use NewFileHook from hook package
 		if err != nil {
 			l.handleError(newErrorf("failed to create error file hook: %v", err))
 		} else {
 			l.errorFileHook = errorHook
-			l.hooks = append(l.hooks, errorHook)
+			*l.hooks = append(*l.hooks, errorHook)
 		}
 	}
 
@@ -264,9 +331,10 @@ func New(config LoggerConfig) *Logger {
 	
 l.setupWriters()
 	
-	if config.EnableSampling && config.SamplingRate > 1 {
-		l.sampler = sampler.NewSamplingLogger(l, config.SamplingRate)
+	if config.EnableSampling {
+		l.SetSamplingRate(config.SamplingRate)
 	}
+	l.SetSampler(config.Sampler)
 
 	if config.AsyncLogging {
 		l.asyncLogger = writer.NewAsyncLogger(l, config.AsyncWorkerCount, config.AsyncLogChannelBufferSize, config.LogProcessTimeout, config.DisablePerLogContextTimeout)
@@ -278,12 +346,20 @@ l.setupWriters()
 func (l *Logger) setupWriters() {
 	currentWriter := l.Config.Output
 
+	if len(l.Config.Sinks) > 0 {
+		l.setupSinks()
+		l.out = currentWriter
+		return
+	}
+
 	if l.Config.EnableRotation && l.Config.RotationConfig != nil {
 		if file, ok := currentWriter.(*os.File); ok {
 			var err error
 			l.rotation, err = writer.NewRotatingFileWriter(file.Name(), l.Config.RotationConfig)
 			if err == nil {
 				currentWriter = l.rotation
+				// The rotating writer opens the path itself
+				l.closeFile(file)
 			} else {
 				l.handleError(newErrorf("failed to setup rotation: %v", err))
 			}
@@ -299,36 +375,108 @@ func (l *Logger) setupWriters() {
 }
 
 
+// closeFile closes f if the logger owns it, see FileConfig.Build, and forgets it
+func (l *Logger) closeFile(f *os.File) {
+	for i, owned := range l.Config.files {
+		if owned != f {
+			continue
+		}
+		l.Config.files = append(l.Config.files[:i:i], l.Config.files[i+1:]...)
+		if err := f.Close(); err != nil {
+			l.handleError(newErrorf("error closing %s: %v", f.Name(), err))
+		}
+		return
+	}
+}
+
 // These methods are to satisfy interfaces for async/sampler writers
 func (l *Logger) Log(ctx context.Context, level core.Level, msg []byte, fields map[string][]byte) {
     l.writeByte(ctx, level, msg, fields)
 }
+func (l *Logger) LogFields(ctx context.Context, level core.Level, msg []byte, fields map[string][]byte, typed []core.Field) {
+	l.writeFields(ctx, level, msg, fields, typed)
+}
+func (l *Logger) WriteEntry(entry *core.LogEntry) {
+	l.writeEntry(entry)
+}
 func (l *Logger) ErrorHandler() func(error) { return l.handleError }
+
+// Stats returns the statistics of the logger, shared with the loggers derived from it
+func (l *Logger) Stats() *LoggerStats { return l.stats }
+
+// GetLevel returns the current minimum level of the logger
+func (l *Logger) GetLevel() core.Level {
+	return core.Level(l.level.Load())
+}
+
+// SetLevel changes the minimum level of the logger at runtime.
+// The level is shared by the logger and all loggers derived from it with With* methods,
+// so changing it affects the whole family. An actual change is reported as a
+// *LevelChangeEvent, see configChanged.
+func (l *Logger) SetLevel(level core.Level) {
+	previous := core.Level(l.level.Swap(int32(level)))
+	if previous != level {
+		l.configChanged(&LevelChangeEvent{Previous: previous, Current: level})
+	}
+}
+
+// configChanged reports a runtime configuration change to LoggerConfig.OnConfigChange.
+// Without it the change takes the diagnostic path: the error handler if one is set,
+// otherwise a "logger: " line on the error output.
+func (l *Logger) configChanged(event ConfigEvent) {
+	if l.Config.OnConfigChange != nil {
+		l.Config.OnConfigChange(event)
+		return
+	}
+	if l.Config.ErrorHandler != nil {
+		l.Config.ErrorHandler(event)
+		return
+	}
+
+	l.errOutMu.Lock()
+	defer l.errOutMu.Unlock()
+	buf := util.GetBufferFromPool()
+	defer util.PutBufferToPool(buf)
+	buf.WriteString("logger: ")
+	event.AppendError(buf)
+	buf.WriteByte('\n')
+	l.errOut.Write(buf.Bytes())
+}
 func (l *Logger) ErrOut() io.Writer { return l.errOut }
-func (l *Logger) ErrOutMu() *sync.Mutex { return &l.errOutMu }
+func (l *Logger) ErrOutMu() *sync.Mutex { return l.errOutMu }
 
 
 // internal logging method optimized for 1M+ logs/second with interface{} fields (for backward compatibility)
 // Early filtering to avoid unnecessary work
 func (l *Logger) log(ctx context.Context, level core.Level, message []byte, fields map[string]interface{}) {
+	// Switch to the context's logger and fields if enabled
+	l, typed := l.resolveContextLogger(ctx, nil)
+
 	// Early return if logger is closed
 	if l.closed.Load() {
 		return
 	}
 
 	// Early filtering to avoid unnecessary work - branch prediction optimized
-	if level < l.Config.Level {
+	if !l.levelEnabled(level, 0, logCallerSkip) {
 		return
 	}
 
     // Sampling if enabled
-    if l.sampler != nil && !l.sampler.ShouldLog() {
+    if l.sampledOut() {
         return
 	}
 
-	// Convert interface{} fields to []byte fields for zero-allocation processing
-	byteFields := make(map[string][]byte)
+	// Drop repetitions of the previous message
+	if l.duplicate(level, message, logCallerSkip) {
+		return
+	}
+
+	// Convert interface{} fields to []byte fields for zero-allocation processing,
+	// keeping non-string values typed so formatters can emit them natively
+	var byteFields map[string][]byte
 	if fields != nil {
+		byteFields = make(map[string][]byte, len(fields))
 		for k, v := range fields {
 			switch value := v.(type) {
 			case string:
@@ -336,106 +484,171 @@ func (l *Logger) log(ctx context.Context, level core.Level, message []byte, fiel
 			case []byte:
 				byteFields[k] = value
 			default:
-				byteFields[k] = core.StringToBytes(fmt.Sprintf("%v", value))
+				typed = append(typed, core.Any(k, value))
 			}
 		}
 	}
 
+	if pc := l.samplerPC(0, logCallerSkip); pc != 0 {
+		l.dispatchBuilt(ctx, level, message, byteFields, typed, pc, time.Time{})
+		return
+	}
+
 	// Optimized path for non-blocking scenarios using atomic operations
-	if l.asyncLogger != nil {
+	if async := l.asyncFor(level); async != nil {
 		// Use lock-free async logging for high throughput
-		l.asyncLogger.Log(level, message, byteFields, ctx)
+		async.LogFields(level, message, byteFields, typed, ctx)
 		return
 	}
 
 	// Hot path is efficient
-	l.writeByte(ctx, level, message, byteFields)
+	l.writeFields(ctx, level, message, byteFields, typed)
 }
 
 // internal logging method optimized for 1M+ logs/second with []byte fields (zero-allocation)
 // Early filtering to avoid unnecessary work
 func (l *Logger) logByte(ctx context.Context, level core.Level, message []byte, fields map[string][]byte) {
+	l.logFields(ctx, level, message, fields, nil)
+}
+
+// internal logging method for []byte and typed fields
+// Early filtering to avoid unnecessary work
+func (l *Logger) logFields(ctx context.Context, level core.Level, message []byte, fields map[string][]byte, typed []core.Field) {
+	// Switch to the context's logger and fields if enabled
+	l, typed = l.resolveContextLogger(ctx, typed)
+
 	// Early return if logger is closed
 	if l.closed.Load() {
 		return
 	}
 
 	// Early filtering to avoid unnecessary work - branch prediction optimized
-	if level < l.Config.Level {
+	if !l.levelEnabled(level, 0, logCallerSkip) {
 		return
 	}
 
+	l.dispatch(ctx, level, message, fields, typed, 0, time.Time{})
+}
+
+// dispatch hands an entry that passed the closed and level checks to the async logger or
+// writes it. pc is the call site if known, e.g. from a slog.Record, and 0 otherwise.
+// ts is the time of the entry if it was not logged now, and zero otherwise.
+func (l *Logger) dispatch(ctx context.Context, level core.Level, message []byte, fields map[string][]byte, typed []core.Field, pc uintptr, ts time.Time) {
     // Sampling if enabled
-    if l.sampler != nil && !l.sampler.ShouldLog() {
+    if l.sampledOut() {
         return
 	}
 
+	// Drop repetitions of the previous message
+	if l.duplicate(level, message, logCallerSkip+1) {
+		return
+	}
+
+	if pc = l.samplerPC(pc, logCallerSkip+1); pc != 0 || !ts.IsZero() {
+		l.dispatchBuilt(ctx, level, message, fields, typed, pc, ts)
+		return
+	}
+
 	// Optimized path for non-blocking scenarios using atomic operations
-	if l.asyncLogger != nil {
+	if async := l.asyncFor(level); async != nil {
 		// Use lock-free async logging for high throughput
-		l.asyncLogger.Log(level, message, fields, ctx)
+		async.LogFields(level, message, fields, typed, ctx)
 		return
 	}
 
 	// Hot path is efficient
-	l.writeByte(ctx, level, message, fields)
+	l.writeFields(ctx, level, message, fields, typed)
 }
 
-	// final write to output dengan zero-allocation optimizations for interface{} fields (backward compatibility)
-func (l *Logger) write(ctx context.Context, level core.Level, message []byte, fields map[string]interface{}) {
-	entry := l.buildEntry(ctx, level, message, fields)
-
-	// Gunakan buffer yang efisien untuk zero-allocation
-	buf := util.GetBufferFromPool()
-	defer util.PutBufferToPool(buf)
+// dispatchBuilt builds the entry on the calling goroutine so it carries the call site
+// pc for a sampler that keys entries by caller and the time ts if not zero, then
+// dispatches it
+func (l *Logger) dispatchBuilt(ctx context.Context, level core.Level, message []byte, fields map[string][]byte, typed []core.Field, pc uintptr, ts time.Time) {
+	if l.asyncFor(level) != nil {
+		message = append([]byte(nil), message...) // The caller may reuse it once we return
+	}
+	entry := l.buildEntryByte(ctx, level, message, fields, typed)
+	entry.PC = pc
+	if !ts.IsZero() {
+		entry.Timestamp = ts
+	}
+	l.dispatchEntry(entry)
+}
 
-	if err := l.formatter.Format(buf, entry); err != nil {
-		l.handleError(err)
-		core.PutEntryToPool(entry)
+// dispatchEntry hands a built entry to the async logger, or writes it when there is
+// no async logger or it cannot take the entry
+func (l *Logger) dispatchEntry(entry *core.LogEntry) {
+	if async := l.asyncFor(entry.Level); async != nil && async.LogEntry(entry) {
 		return
 	}
+	l.writeEntry(entry)
+}
 
-    bytesToWrite := buf.Bytes()
+// asyncFor returns the async logger to use for level, or nil to write synchronously.
+// FATAL and PANIC entries are always written by the caller so they are not lost
+// when the process exits.
+func (l *Logger) asyncFor(level core.Level) *writer.AsyncLogger {
+	if level >= core.FATAL {
+		return nil
+	}
+	return l.asyncLogger
+}
 
-	// Optimized write with minimal locking - only lock when actually writing
-	if l.Config.DisableLocking {
-		// Direct path: no locking at all
-		if n, err := l.out.Write(bytesToWrite); err != nil {
-			l.handleError(err)
-		} else {
-			l.stats.Increment(level, n)
-		}
-	} else {
-		// Standard path with proper locking
-		l.mu.Lock()
-		if n, err := l.out.Write(bytesToWrite); err != nil {
-			l.handleError(err)
-		} else {
-			l.stats.Increment(level, n)
-		}
-		l.mu.Unlock()
+	// final write to output dengan zero-allocation optimizations for []byte fields (true zero-allocation)
+func (l *Logger) writeByte(ctx context.Context, level core.Level, message []byte, fields map[string][]byte) {
+	l.writeFields(ctx, level, message, fields, nil)
+}
+
+// writeFields writes an entry built from []byte and typed fields to the output
+func (l *Logger) writeFields(ctx context.Context, level core.Level, message []byte, fields map[string][]byte, typed []core.Field) {
+	l.writeEntry(l.buildEntryByte(ctx, level, message, fields, typed))
+}
+
+// writeEntry formats and writes a built entry, runs hooks and level actions,
+// and returns the entry to the pool
+func (l *Logger) writeEntry(entry *core.LogEntry) {
+	if l.sampleEntry(entry) {
+		l.writeSampled(entry)
+	}
+	core.PutEntryToPool(entry)
+}
+
+// writeSampled formats and writes an entry that passed the sampler, then runs hooks
+// and level actions
+func (l *Logger) writeSampled(entry *core.LogEntry) {
+	level := entry.Level
+
+	exitCtx := context.Background()
+	if level >= core.FATAL {
+		var cancel context.CancelFunc
+		exitCtx, cancel = l.beginExit()
+		defer cancel()
+	}
+
+	if l.sinks != nil {
+		l.stats.Increment(level, l.writeSinks(entry))
+	} else if !l.writeOutput(entry) {
+		return
 	}
 
 	l.runHooks(entry)
 
     // must be done after hooks and writing, but before PutEntryToPool
-	l.handleLevelActions(level, entry)
-
-	core.PutEntryToPool(entry)
+	l.handleLevelActions(exitCtx, level, entry)
 }
 
-	// final write to output dengan zero-allocation optimizations for []byte fields (true zero-allocation)
-func (l *Logger) writeByte(ctx context.Context, level core.Level, message []byte, fields map[string][]byte) {
-	entry := l.buildEntryByte(ctx, level, message, fields)
+// writeOutput formats the entry with the logger's formatter and writes it to the output.
+// It reports false if the entry could not be formatted.
+func (l *Logger) writeOutput(entry *core.LogEntry) bool {
+	level := entry.Level
 
 	// Gunakan buffer yang efisien untuk zero-allocation
 	buf := util.GetBufferFromPool()
 	defer util.PutBufferToPool(buf)
 
-	if err := l.formatter.Format(buf, entry); err != nil {
+	if err := l.formatter.Load().Format(buf, entry); err != nil {
 		l.handleError(err)
-		core.PutEntryToPool(entry)
-		return
+		return false
 	}
 
     bytesToWrite := buf.Bytes()
@@ -458,13 +671,7 @@ func (l *Logger) writeByte(ctx context.Context, level core.Level, message []byte
 		}
 		l.mu.Unlock()
 	}
-
-	l.runHooks(entry)
-
-    // must be done after hooks and writing, but before PutEntryToPool
-	l.handleLevelActions(level, entry)
-
-	core.PutEntryToPool(entry)
+	return true
 }
 
 // formatArgsToBytes formats variadic arguments into a byte slice with minimal allocations.
@@ -568,8 +775,8 @@ func (l *Logger) formatfArgsToBytes(format string, args ...interface{}) []byte {
 	return result
 }
 
-// buildEntry creates a log entry with minimal allocations
-func (l *Logger) buildEntry(ctx context.Context, level core.Level, message []byte, fields map[string]interface{}) *core.LogEntry {
+// buildEntryByte creates a log entry with minimal allocations using []byte fields (true zero-allocation)
+func (l *Logger) buildEntryByte(ctx context.Context, level core.Level, message []byte, fields map[string][]byte, typed []core.Field) *core.LogEntry {
     entry := core.GetEntryFromPool()
 
     // Use clock if available to avoid allocation
@@ -584,29 +791,23 @@ func (l *Logger) buildEntry(ctx context.Context, level core.Level, message []byt
 	entry.Message = message
 	entry.PID = l.pid
 
-	// Copy fields with minimal allocations - l.fields is now []byte
+	// Copy fields with minimal allocations - these are already []byte
 	for k, v := range l.fields {
-		entry.Fields[k] = v // v is already []byte
+		entry.Fields[k] = v
 	}
-	if fields != nil {
-		for k, v := range fields {
-			// Convert interface{} values to []byte when copying to entry.Fields
-			switch val := v.(type) {
-			case string:
-				entry.Fields[k] = core.StringToBytes(val)
-			case []byte:
-				entry.Fields[k] = val
-			default:
-				entry.Fields[k] = core.StringToBytes(fmt.Sprintf("%v", val))
-			}
-		}
+	l.applyEntryDefaults(entry)
+	for k, v := range fields {
+		setEntryField(entry, k, v)
+	}
+	for i := range typed {
+		addTypedField(entry, typed[i])
 	}
 
 	// Extract context with zero allocation if possible
 	if l.contextExtractor != nil {
 		contextFields := l.contextExtractor(ctx)
 		for k, v := range contextFields {
-			entry.Fields[k] = v
+			entry.Fields[k] = v // v is already []byte
 		}
 	} else if ctx != nil {
         contextData := util.ExtractFromContext(ctx)
@@ -624,7 +825,7 @@ func (l *Logger) buildEntry(ctx context.Context, level core.Level, message []byt
 
 	// Caller info only if required to avoid overhead
 	if l.Config.ShowCaller {
-		entry.Caller = util.GetCallerInfo(l.Config.CallerDepth)
+		entry.Caller = util.GetCallerInfo(l.Config.CallerDepth + l.callerSkip)
 	}
 
     // Stack trace only for ERROR level and above
@@ -637,81 +838,104 @@ func (l *Logger) buildEntry(ctx context.Context, level core.Level, message []byt
 	return entry
 }
 
-// buildEntryByte creates a log entry with minimal allocations using []byte fields (true zero-allocation)
-func (l *Logger) buildEntryByte(ctx context.Context, level core.Level, message []byte, fields map[string][]byte) *core.LogEntry {
-    entry := core.GetEntryFromPool()
-
-    // Use clock if available to avoid allocation
-    if l.clock != nil {
-        entry.Timestamp = l.clock.Now()
-    } else {
-        entry.Timestamp = time.Now()
-    }
-
-	entry.Level = level
-	entry.LevelName = level.ToBytes()
-	entry.Message = message
-	entry.PID = l.pid
-
-	// Copy fields with minimal allocations - these are already []byte
-	for k, v := range l.fields {
-		entry.Fields[k] = v
+// applyEntryDefaults copies the logger's name, default error, duration, tags, metrics
+// and typed fields into the entry
+func (l *Logger) applyEntryDefaults(entry *core.LogEntry) {
+	if l.name != "" {
+		entry.LoggerName = core.StringToBytes(l.name)
 	}
-	if fields != nil {
-		for k, v := range fields {
-			entry.Fields[k] = v
-		}
+	if l.err != nil {
+		entry.Error = l.err
+	}
+	if l.duration != 0 {
+		entry.Duration = l.duration
 	}
+	entry.Tags = append(entry.Tags, l.tags...)
+	for k, v := range l.customMetrics {
+		entry.CustomMetrics[k] = v
+	}
+	for i := range l.typedFields {
+		addTypedField(entry, l.typedFields[i])
+	}
+}
 
-	// Extract context with zero allocation if possible
-	if l.contextExtractor != nil {
-		contextFields := l.contextExtractor(ctx)
-		for k, v := range contextFields {
-			entry.Fields[k] = v // v is already []byte
+// addTypedField stores a typed field in the entry. Errors keyed "error",
+// durations keyed "duration", tags and metrics go to their dedicated entry slots
+// so formatters render them in their own sections.
+func addTypedField(entry *core.LogEntry, f core.Field) {
+	switch f.Type {
+	case core.ErrorType:
+		if f.Key == core.ErrorKey {
+			if err, ok := f.Interface.(error); ok && err != nil {
+				entry.Error = err
+				return
+			}
 		}
-	} else if ctx != nil {
-        contextData := util.ExtractFromContext(ctx)
-        for k, v := range contextData {
-            switch k {
-            case "trace_id": entry.TraceID = core.StringToBytes(v)
-            case "span_id": entry.SpanID = core.StringToBytes(v)
-            case "user_id": entry.UserID = core.StringToBytes(v)
-            case "session_id": entry.SessionID = core.StringToBytes(v)
-            case "request_id": entry.RequestID = core.StringToBytes(v)
-            }
-        }
-        util.PutMapStringToPool(contextData)
-    }
-
-	// Caller info only if required to avoid overhead
-	if l.Config.ShowCaller {
-		entry.Caller = util.GetCallerInfo(l.Config.CallerDepth)
+	case core.DurationType:
+		if f.Key == core.DurationKey {
+			entry.Duration = time.Duration(f.Integer)
+			return
+		}
+	case core.TagsType:
+		tags, _ := f.Interface.([]string)
+		for _, tag := range tags {
+			entry.Tags = append(entry.Tags, core.StringToBytes(tag))
+		}
+		return
+	case core.MetricType:
+		entry.CustomMetrics[f.Key] = f.Float64Value()
+		return
 	}
+	putTypedField(entry, f)
+}
 
-    // Stack trace only for ERROR level and above
-    if l.Config.EnableStackTrace && level >= core.ERROR {
-        stackTraceBytes, stackTraceBufPtr := util.GetStackTrace(l.Config.StackTraceDepth)
-        entry.StackTrace = stackTraceBytes
-        entry.StackTraceBufPtr = stackTraceBufPtr
-    }
-
-	return entry
+// putTypedField adds f to the typed fields of the entry. A later field replaces an
+// earlier typed or []byte field with the same key, so formatters never write a key twice.
+func putTypedField(entry *core.LogEntry, f core.Field) {
+	delete(entry.Fields, f.Key)
+	for i := range entry.TypedFields {
+		if entry.TypedFields[i].Key == f.Key {
+			entry.TypedFields[i] = f
+			return
+		}
+	}
+	entry.TypedFields = append(entry.TypedFields, f)
 }
 
+// setEntryField stores a []byte field in the entry, replacing a typed field with the same key
+func setEntryField(entry *core.LogEntry, key string, value []byte) {
+	entry.Fields[key] = value
+	for i := range entry.TypedFields {
+		if entry.TypedFields[i].Key == key {
+			entry.TypedFields = append(entry.TypedFields[:i], entry.TypedFields[i+1:]...)
+			return
+		}
+	}
+}
 
 // runHooks executes hooks with minimal lock contention
 func (l *Logger) runHooks(entry *core.LogEntry) {
+	// Hooks were closed by a FATAL or PANIC entry
+	if l.hooksClosed.Load() {
+		return
+	}
+
 	// Gunakan RLock untuk read-only access
 	l.mu.RLock()
 	defer l.mu.RUnlock()
 
 	// Early return jika tidak ada hooks
-	if len(l.hooks) == 0 {
+	if len(*l.configHooks) == 0 && len(*l.hooks) == 0 {
 		return
 	}
 
 	// Execute hooks dengan graceful error handling
-	for _, h := range l.hooks {
+	for _, h := range *l.configHooks {
+		if err := h.Fire(entry); err != nil {
+			l.handleError(newErrorf("hook error: %v", err))
+		}
+	}
+	for _, h := range *l.hooks {
 		if err := h.Fire(entry); err != nil {
 			l.handleError(newErrorf("hook error: %v", err))
 		}
@@ -722,15 +946,63 @@ func (l *Logger) runHooks(entry *core.LogEntry) {
 func (l *Logger) AddHook(h hook.Hook) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	l.hooks = append(l.hooks, h)
+	*l.hooks = append(*l.hooks, h)
+}
+
+// beginExit drains the async queue before a FATAL or PANIC entry is written, so the
+// entries logged before it are written first. It returns the context that bounds the
+// whole flush before exiting, see LoggerConfig.ExitFlushTimeout.
+func (l *Logger) beginExit() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), l.Config.ExitFlushTimeout)
+	if l.asyncLogger != nil {
+		if err := l.asyncLogger.Flush(ctx); err != nil {
+			l.handleError(newErrorf("error draining async logger before exit: %v", err))
+		}
+	}
+	return ctx, cancel
+}
+
+// flushForExit flushes the buffered writer before the process exits or panics, and
+// closes the hooks if closeHooks is set. It gives up when ctx is done so a stuck writer
+// cannot block the exit.
+func (l *Logger) flushForExit(ctx context.Context, closeHooks bool) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := l.FlushContext(ctx); err != nil {
+			l.handleError(newErrorf("error flushing before exit: %v", err))
+		}
+		if closeHooks && l.hooksClosed.CompareAndSwap(false, true) {
+			l.mu.RLock()
+			hooks := append(append([]hook.Hook(nil), *l.configHooks...), *l.hooks...)
+			l.mu.RUnlock()
+			for _, h := range hooks {
+				if err := h.Close(); err != nil {
+					l.handleError(newErrorf("error closing hook before exit: %v", err))
+				}
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		l.handleError(newErrorf("flushing before exit did not finish within %v", l.Config.ExitFlushTimeout))
+	}
 }
 
-func (l *Logger) handleLevelActions(level core.Level, entry *core.LogEntry) {
+// handleLevelActions runs the FATAL and PANIC actions once the entry has been written.
+// Writers are flushed first, bounded by ctx. Hooks are only closed when the process
+// exits through the default ExitFunc; a custom ExitFunc or PanicOnPanicLevel may let
+// the program keep logging.
+func (l *Logger) handleLevelActions(ctx context.Context, level core.Level, entry *core.LogEntry) {
+	exits := l.Config.ExitFunc == nil
 	switch level {
 	case core.FATAL:
 		if l.onFatal != nil {
 			l.onFatal(entry)
 		}
+		l.flushForExit(ctx, exits)
 		l.exitFunc(1)
 	case core.PANIC:
 		if l.onPanic != nil {
@@ -746,6 +1018,10 @@ func (l *Logger) handleLevelActions(level core.Level, entry *core.LogEntry) {
 			msgBuf.WriteByte('\n')
 			l.out.Write(msgBuf.Bytes())
 		}
+		l.flushForExit(ctx, exits && !l.Config.PanicOnPanicLevel)
+		if l.Config.PanicOnPanicLevel {
+			panic(string(entry.Message))
+		}
 		l.exitFunc(1)
 	}
 }
@@ -770,16 +1046,78 @@ func (l *Logger) handleError(err error) {
 	}
 }
 
+// syncer is implemented by outputs that can commit written data to stable storage, such as *os.File
+type syncer interface {
+	Sync() error
+}
+
+// Sync blocks until every entry accepted so far has been written, then syncs file outputs.
+// It is FlushContext without a deadline.
+func (l *Logger) Sync() error {
+	return l.FlushContext(context.Background())
+}
+
+// FlushContext blocks until every entry accepted so far has been processed by the async
+// workers and written by the buffered writer, then syncs file outputs to stable storage.
+// Entries accepted while it waits are not waited for, so it returns under steady load.
+// Unlike Close, the logger stays usable. It returns ctx.Err() if ctx is done first.
+// The logger and its clones share writers, so flushing one flushes all of them.
+func (l *Logger) FlushContext(ctx context.Context) error {
+	// Close already flushed everything
+	if l.closed.Load() {
+		return nil
+	}
+
+	// Write the summary of suppressed repetitions so it is flushed with the rest
+	l.flushDuplicates()
+
+	if l.asyncLogger != nil {
+		if err := l.asyncLogger.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	if l.sinks != nil {
+		return l.flushSinks(ctx)
+	}
+	if l.buffer != nil {
+		if err := l.buffer.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	if l.rotation != nil {
+		return syncWriter(l.rotation)
+	}
+	return syncWriter(l.Config.Output)
+}
+
+// syncWriter syncs w if it supports it. Standard output and standard error are
+// skipped, syncing them fails when they are a terminal or a pipe.
+func syncWriter(w io.Writer) error {
+	if w == os.Stdout || w == os.Stderr {
+		return nil
+	}
+	if s, ok := w.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
 // Close gracefully closes the logger and its writers.
 // Handles all edge cases with graceful degradation
 func (l *Logger) Close() {
 	// Ensure it's only closed once
 	if l.closed.CompareAndSwap(false, true) {
+		// Write the summary of suppressed repetitions while the writers are still open
+		l.flushDuplicates()
+
 		// Close async logger if present
 		if l.asyncLogger != nil {
 			l.asyncLogger.Close()
 		}
 
+		// Close sink buffers if present
+		l.closeSinks()
+
 		// Close buffered writer if present
 		if l.buffer != nil {
 			// Graceful degradation during closing
@@ -796,13 +1134,18 @@ func (l *Logger) Close() {
 			}
 		}
 
+		// Close the outputs opened from a config file
+		for _, f := range l.Config.files {
+			l.closeFile(f)
+		}
+
 		// Stop clock if present
 		if l.clock != nil {
 			l.clock.Stop()
 		}
 
-		// Close error file hook if present
-		if l.errorFileHook != nil {
+		// Close error file hook if present and not already closed by a FATAL or PANIC entry
+		if l.errorFileHook != nil && !l.hooksClosed.Load() {
 			// Graceful degradation during closing
 			if err := l.errorFileHook.Close(); err != nil {
 				l.handleError(newErrorf("error closing error file hook: %v", err))
@@ -817,6 +1160,18 @@ func (l *Logger) WithFieldsBytes(fields map[string][]byte) *Logger {
 	newLogger := l.clone()
 	for k, v := range fields {
 		newLogger.fields[k] = v
+		newLogger.typedFields = removeTypedField(newLogger.typedFields, k)
+	}
+	return newLogger
+}
+
+// With creates a new logger with additional typed fields
+// These fields keep their value type in all log entries made with the returned logger
+func (l *Logger) With(fields ...core.Field) *Logger {
+	newLogger := l.clone()
+	for _, f := range fields {
+		delete(newLogger.fields, f.Key)
+		newLogger.typedFields = setTypedField(newLogger.typedFields, f)
 	}
 	return newLogger
 }
@@ -826,19 +1181,106 @@ func (l *Logger) WithFieldsBytes(fields map[string][]byte) *Logger {
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
 	newLogger := l.clone()
 	for k, v := range fields {
-		// Convert interface{} values to []byte where possible
+		// Convert interface{} values to []byte where possible, other values stay typed
 		switch val := v.(type) {
 		case string:
 			newLogger.fields[k] = core.StringToBytes(val)
+			newLogger.typedFields = removeTypedField(newLogger.typedFields, k)
 		case []byte:
 			newLogger.fields[k] = val
+			newLogger.typedFields = removeTypedField(newLogger.typedFields, k)
 		default:
-			newLogger.fields[k] = core.StringToBytes(fmt.Sprintf("%v", val))
+			delete(newLogger.fields, k)
+			newLogger.typedFields = setTypedField(newLogger.typedFields, core.Any(k, val))
 		}
 	}
 	return newLogger
 }
 
+// Named creates a child logger whose name is the parent name and the given name joined
+// with a dot, so Named("db").Named("pool") is named "db.pool". Formatters emit the
+// name as the "logger" field and level rules match it as the component.
+func (l *Logger) Named(name string) *Logger {
+	newLogger := l.clone()
+	if name == "" {
+		return newLogger
+	}
+	if l.name == "" {
+		newLogger.name = name
+	} else {
+		newLogger.name = l.name + "." + name
+	}
+	return newLogger
+}
+
+// Name returns the dotted name of the logger, or "" for an unnamed logger
+func (l *Logger) Name() string {
+	return l.name
+}
+
+// AddCallerSkip returns a logger that skips extra stack frames when it looks up the
+// call site for caller info and level rules. Wrappers around the logging methods use
+// it so that the caller of the wrapper is reported instead of the wrapper itself.
+// The returned logger shares the hooks added with AddHook with l, before and after
+// the call.
+func (l *Logger) AddCallerSkip(skip int) *Logger {
+	newLogger := l.clone()
+	newLogger.callerSkip += skip
+	newLogger.hooks = l.hooks
+	return newLogger
+}
+
+// WithError creates a new logger that attaches err to all log entries
+func (l *Logger) WithError(err error) *Logger {
+	newLogger := l.clone()
+	newLogger.err = err
+	return newLogger
+}
+
+// WithDuration creates a new logger that attaches an operation duration to all log entries
+func (l *Logger) WithDuration(d time.Duration) *Logger {
+	newLogger := l.clone()
+	newLogger.duration = d
+	return newLogger
+}
+
+// WithTags creates a new logger with additional tags for all log entries
+func (l *Logger) WithTags(tags ...string) *Logger {
+	newLogger := l.clone()
+	for _, tag := range tags {
+		newLogger.tags = append(newLogger.tags, core.StringToBytes(tag))
+	}
+	return newLogger
+}
+
+// WithMetric creates a new logger that attaches a named metric to all log entries
+func (l *Logger) WithMetric(name string, value float64) *Logger {
+	newLogger := l.clone()
+	newLogger.customMetrics[name] = value
+	return newLogger
+}
+
+// setTypedField replaces the field with the same key or appends a new one
+func setTypedField(fields []core.Field, f core.Field) []core.Field {
+	for i := range fields {
+		if fields[i].Key == f.Key {
+			fields[i] = f
+			return fields
+		}
+	}
+	return append(fields, f)
+}
+
+// removeTypedField removes the field with the given key if present
+func removeTypedField(fields []core.Field, key string) []core.Field {
+	for i := range fields {
+		if fields[i].Key == key {
+			return append(fields[:i], fields[i+1:]...)
+		}
+	}
+	return fields
+}
+
 // clone creates a copy of the logger with shared resources
 func (l *Logger) clone() *Logger {
     l.mu.RLock()
@@ -855,6 +1297,16 @@ func (l *Logger) clone() *Logger {
     for k, v := range l.fields {
         cloned.fields[k] = v
     }
+    // Copy typed fields so appends on the clone never touch the parent's backing array
+    cloned.typedFields = append([]core.Field(nil), l.typedFields...)
+    cloned.tags = append([][]byte(nil), l.tags...)
+    // Hooks added to the clone later do not reach the parent
+    hooks := append([]hook.Hook(nil), *l.hooks...)
+    cloned.hooks = &hooks
+    cloned.customMetrics = make(map[string]float64, len(l.customMetrics)+1)
+    for k, v := range l.customMetrics {
+        cloned.customMetrics[k] = v
+    }
 
     return &cloned
 }
@@ -1007,6 +1459,56 @@ func (l *Logger) FatalfC(ctx context.Context, format string, args ...interface{}
 // PanicfC logs a formatted message with PANIC level and extracts context information, then panics
 func (l *Logger) PanicfC(ctx context.Context, format string, args ...interface{}) { l.log(ctx, core.PANIC, l.formatfArgsToBytes(format, args...), nil) }
 
+// Typed field logging methods
+
+// TraceFields logs a message with TRACE level and typed fields
+func (l *Logger) TraceFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.TRACE, core.StringToBytes(message), nil, fields) }
+
+// DebugFields logs a message with DEBUG level and typed fields
+func (l *Logger) DebugFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.DEBUG, core.StringToBytes(message), nil, fields) }
+
+// InfoFields logs a message with INFO level and typed fields
+func (l *Logger) InfoFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.INFO, core.StringToBytes(message), nil, fields) }
+
+// NoticeFields logs a message with NOTICE level and typed fields
+func (l *Logger) NoticeFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.NOTICE, core.StringToBytes(message), nil, fields) }
+
+// WarnFields logs a message with WARN level and typed fields
+func (l *Logger) WarnFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.WARN, core.StringToBytes(message), nil, fields) }
+
+// ErrorFields logs a message with ERROR level and typed fields
+func (l *Logger) ErrorFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.ERROR, core.StringToBytes(message), nil, fields) }
+
+// FatalFields logs a message with FATAL level and typed fields and exits the application
+func (l *Logger) FatalFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.FATAL, core.StringToBytes(message), nil, fields) }
+
+// PanicFields logs a message with PANIC level and typed fields and panics
+func (l *Logger) PanicFields(message string, fields ...core.Field) { l.logFields(context.Background(), core.PANIC, core.StringToBytes(message), nil, fields) }
+
+// TraceFieldsC logs a message with TRACE level and typed fields and extracts context information
+func (l *Logger) TraceFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.TRACE, core.StringToBytes(message), nil, fields) }
+
+// DebugFieldsC logs a message with DEBUG level and typed fields and extracts context information
+func (l *Logger) DebugFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.DEBUG, core.StringToBytes(message), nil, fields) }
+
+// InfoFieldsC logs a message with INFO level and typed fields and extracts context information
+func (l *Logger) InfoFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.INFO, core.StringToBytes(message), nil, fields) }
+
+// NoticeFieldsC logs a message with NOTICE level and typed fields and extracts context information
+func (l *Logger) NoticeFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.NOTICE, core.StringToBytes(message), nil, fields) }
+
+// WarnFieldsC logs a message with WARN level and typed fields and extracts context information
+func (l *Logger) WarnFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.WARN, core.StringToBytes(message), nil, fields) }
+
+// ErrorFieldsC logs a message with ERROR level and typed fields and extracts context information
+func (l *Logger) ErrorFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.ERROR, core.StringToBytes(message), nil, fields) }
+
+// FatalFieldsC logs a message with FATAL level and typed fields and extracts context information, then exits the application
+func (l *Logger) FatalFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.FATAL, core.StringToBytes(message), nil, fields) }
+
+// PanicFieldsC logs a message with PANIC level and typed fields and extracts context information, then panics
+func (l *Logger) PanicFieldsC(ctx context.Context, message string, fields ...core.Field) { l.logFields(ctx, core.PANIC, core.StringToBytes(message), nil, fields) }
+
 // manualFormatValue formats a value without using fmt package
 func manualFormatValue(buf *bytes.Buffer, v interface{}) {
 	switch val := v.(type) {
@@ -1037,6 +1539,10 @@ func manualFormatValue(buf *bytes.Buffer, v interface{}) {
 		}
 	case nil:
 		buf.Write([]byte("<nil>"))
+	case error:
+		buf.WriteString(val.Error())
+	case interface{ String() string }:
+		buf.WriteString(val.String())
 	default:
 		// For remaining types, we'll use a simple representation
 		buf.Write([]byte("<unknown-type>"))
@@ -1100,6 +1606,34 @@ func manualFormatWithArgs(buf *bytes.Buffer, format string, args ...interface{})
 	}
 }
 
+// ConfigEvent is a runtime configuration change, a *LevelChangeEvent or a
+// *ConfigReloadEvent. It is an error so that it can be passed to LoggerConfig.ErrorHandler.
+type ConfigEvent interface {
+	error
+	core.ErrorAppender
+}
+
+// LevelChangeEvent is reported when SetLevel changes the level, see LoggerConfig.OnConfigChange
+type LevelChangeEvent struct {
+	Previous core.Level // Level before the change
+	Current  core.Level // Level after the change
+}
+
+// AppendError implements the ErrorAppender interface for LevelChangeEvent
+func (e *LevelChangeEvent) AppendError(buf *bytes.Buffer) {
+	buf.WriteString("log level changed from ")
+	buf.Write(e.Previous.Bytes())
+	buf.WriteString(" to ")
+	buf.Write(e.Current.Bytes())
+}
+
+// Error returns the event description
+func (e *LevelChangeEvent) Error() string {
+	var buf bytes.Buffer
+	e.AppendError(&buf)
+	return buf.String()
+}
+
 // errorString creates an error with string content without fmt dependency
 type errorString struct {
 	s string
@@ -3,18 +3,42 @@ package logger
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"os"
+	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/Lunar-Chipter/mire/core"
 	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/hook"
 	"github.com/Lunar-Chipter/mire/util"
 )
 
+// TestNewErrorfValues tests that errors and fmt.Stringer values are formatted by their text
+func TestNewErrorfValues(t *testing.T) {
+	tests := []struct {
+		name string
+		arg  interface{}
+		want string
+	}{
+		{"error", errors.New("disk full"), "write failed: disk full"},
+		{"stringer", core.WARN, "write failed: WARN"},
+		{"unknown", struct{}{}, "write failed: <unknown-type>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newErrorf("write failed: %v", tt.arg).Error(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
 // TestLoggerBasicOperations tests basic logger operations
 func TestLoggerBasicOperations(t *testing.T) {
 	var buf bytes.Buffer
@@ -364,9 +388,748 @@ func TestNewDefaultLogger(t *testing.T) {
 	logger.Info("test from default logger")
 }
 
+// TestLoggerTypedFields tests that typed fields keep their native JSON types
+func TestLoggerTypedFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+	defer logger.Close()
+
+	logger.With(core.String("service", "api")).InfoFields("request done",
+		core.Int("status", 200),
+		core.Duration("latency", 5*time.Millisecond),
+		core.Bool("cached", false),
+	)
+	logger.WithFields(map[string]interface{}{"count": 42, "user": "bob"}).Info("fields")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	for _, want := range []string{`"service":"api"`, `"status":200`, `"latency":5000000`, `"cached":false`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("Expected %s in %s", want, lines[0])
+		}
+	}
+	for _, want := range []string{`"count":42`, `"user":"bob"`} {
+		if !strings.Contains(lines[1], want) {
+			t.Errorf("Expected %s in %s", want, lines[1])
+		}
+	}
+}
+
+// TestLoggerTypedFieldsAsync tests that typed fields survive the async path
+func TestLoggerTypedFieldsAsync(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:                       core.INFO,
+		Output:                      &buf,
+		Formatter:                   formatter.NewJSONFormatter(),
+		AsyncLogging:                true,
+		AsyncWorkerCount:            1,
+		AsyncLogChannelBufferSize:   10,
+		LogProcessTimeout:           time.Second,
+		DisablePerLogContextTimeout: true,
+	})
+
+	logger.InfoFields("async typed", core.Int64("n", 7))
+	logger.Close()
+
+	if !strings.Contains(buf.String(), `"n":7`) {
+		t.Errorf("Expected typed field in async output, got %q", buf.String())
+	}
+}
+
+// TestLoggerWithOverridesFields tests that typed and byte fields replace each other by key
+func TestLoggerWithOverridesFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+	defer logger.Close()
+
+	parent := logger.WithFieldsBytes(map[string][]byte{"id": []byte("abc")})
+	child := parent.With(core.Int("id", 1))
+	child.Info("child")
+	parent.Info("parent")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `"id":1`) || strings.Contains(lines[0], `"id":"abc"`) {
+		t.Errorf("Child should only carry the typed id, got %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"id":"abc"`) {
+		t.Errorf("Parent fields should be unchanged, got %s", lines[1])
+	}
+}
+
+// TestLoggerCallFieldsOverrideDefaults tests that a field passed to the log call
+// replaces a logger field with the same key instead of writing the key twice
+func TestLoggerCallFieldsOverrideDefaults(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+	defer logger.Close()
+
+	logger.WithFields(map[string]interface{}{"user": "a"}).InfoFields("x", core.Int("user", 3))
+	logger.With(core.Int("n", 1)).InfoFields("y", core.Int("n", 2))
+	logger.With(core.Int("n", 1)).InfoEvent().Int("n", 3).Msg("z")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", len(lines), buf.String())
+	}
+	for i, tc := range []struct{ key, want string }{
+		{`"user":`, `"fields":{"user":3}`},
+		{`"n":`, `"fields":{"n":2}`},
+		{`"n":`, `"fields":{"n":3}`},
+	} {
+		if strings.Count(lines[i], tc.key) != 1 || !strings.Contains(lines[i], tc.want) {
+			t.Errorf("Expected only %s in %s", tc.want, lines[i])
+		}
+	}
+}
+
+// TestLoggerEntrySlotBuilders tests WithError, WithDuration, WithTags and WithMetric
+func TestLoggerEntrySlotBuilders(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:  core.INFO,
+		Output: &buf,
+		Formatter: &formatter.TextFormatter{
+			EnableColors:   false,
+			ShowTimestamp:  false,
+			ShowCaller:     false,
+			EnableDuration: true,
+		},
+	})
+	defer logger.Close()
+
+	base := logger.WithTags("db")
+	base.WithError(errors.New("connection reset")).
+		WithDuration(42 * time.Millisecond).
+		WithTags("retry").
+		WithMetric("attempts", 2).
+		Error("query failed")
+
+	output := buf.String()
+	for _, want := range []string{"error=connection reset", "(42ms)", "[db,retry]", "attempts=2.00"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got %q", want, output)
+		}
+	}
+
+	// The parent logger must not see the child's values
+	buf.Reset()
+	base.Info("plain")
+	output = buf.String()
+	if strings.Contains(output, "error=") || strings.Contains(output, "retry") || strings.Contains(output, "attempts") {
+		t.Errorf("Parent logger should only carry its own tags, got %q", output)
+	}
+	if !strings.Contains(output, "[db]") {
+		t.Errorf("Expected parent tags in output, got %q", output)
+	}
+}
+
+// TestLoggerEntrySlotFields tests the per-call variants passed as typed fields
+func TestLoggerEntrySlotFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: &formatter.JSONFormatter{EnableDuration: true},
+	})
+	defer logger.Close()
+
+	logger.ErrorFields("query failed",
+		core.Err(errors.New("timeout")),
+		core.Elapsed(time.Second),
+		core.Tags("db"),
+		core.Metric("rows", 0),
+		core.Int("shard", 4),
+	)
+
+	output := buf.String()
+	for _, want := range []string{`"error":"timeout"`, `"duration_ns":1000000000`, `"tags":["db"]`, `"metrics":{"rows":0}`, `"fields":{"shard":4}`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in output, got %s", want, output)
+		}
+	}
+}
+
+// TestLoggerSetLevel tests that the level is shared by clones and can change at runtime
+func TestLoggerSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	var events []error
+	logger := New(LoggerConfig{
+		Level:  core.INFO,
+		Output: &buf,
+		Formatter: &formatter.TextFormatter{
+			EnableColors:  false,
+			ShowTimestamp: false,
+			ShowCaller:    false,
+		},
+		ErrorHandler: func(err error) { events = append(events, err) },
+	})
+	defer logger.Close()
+
+	child := logger.WithFields(map[string]interface{}{"component": "db"})
+	child.Debug("hidden debug")
+	if buf.Len() > 0 {
+		t.Fatalf("Debug should be filtered at INFO, got %q", buf.String())
+	}
+
+	logger.SetLevel(core.DEBUG)
+	if child.GetLevel() != core.DEBUG {
+		t.Errorf("Clone should share the level, got %v", child.GetLevel())
+	}
+	child.Debug("visible debug")
+	if !strings.Contains(buf.String(), "visible debug") {
+		t.Errorf("Debug should be logged after SetLevel(DEBUG), got %q", buf.String())
+	}
+
+	child.SetLevel(core.ERROR)
+	buf.Reset()
+	logger.Warn("hidden warn")
+	if buf.Len() > 0 {
+		t.Errorf("Parent should follow a level set on the clone, got %q", buf.String())
+	}
+
+	// Setting the same level again is not a change
+	logger.SetLevel(core.ERROR)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 level change events, got %d: %v", len(events), events)
+	}
+	var event *LevelChangeEvent
+	if !errors.As(events[1], &event) || event.Previous != core.DEBUG || event.Current != core.ERROR {
+		t.Errorf("Unexpected level change event %v", events[1])
+	}
+	if events[0].Error() != "log level changed from INFO to DEBUG" {
+		t.Errorf("Unexpected event message %q", events[0].Error())
+	}
+}
+
+// TestLoggerLevelChangeReporting tests where level changes are reported
+func TestLoggerLevelChangeReporting(t *testing.T) {
+	var changes []ConfigEvent
+	var errs []error
+	logger := New(LoggerConfig{
+		Level:          core.INFO,
+		Output:         io.Discard,
+		OnConfigChange: func(e ConfigEvent) { changes = append(changes, e) },
+		ErrorHandler:   func(err error) { errs = append(errs, err) },
+	})
+	defer logger.Close()
+
+	logger.SetLevel(core.WARN)
+	if len(changes) != 1 || len(errs) != 0 {
+		t.Errorf("Expected the change only in OnConfigChange, got %v and %v", changes, errs)
+	}
+
+	var errOut bytes.Buffer
+	plain := New(LoggerConfig{Level: core.INFO, Output: io.Discard, ErrorOutput: &errOut})
+	defer plain.Close()
+
+	plain.SetLevel(core.WARN)
+	if want := "logger: log level changed from INFO to WARN\n"; errOut.String() != want {
+		t.Errorf("Expected %q on the error output, got %q", want, errOut.String())
+	}
+}
+
+// TestLoggerSetLevelConcurrent tests changing the level while other goroutines log
+func TestLoggerSetLevelConcurrent(t *testing.T) {
+	logger := New(LoggerConfig{
+		Level:        core.INFO,
+		Output:       io.Discard,
+		Formatter:    &formatter.TextFormatter{},
+		ErrorHandler: func(error) {},
+	})
+	defer logger.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l := logger.WithFields(map[string]interface{}{"worker": "w"})
+			for j := 0; j < 200; j++ {
+				l.Debug("debug")
+				l.Info("info")
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		if j%2 == 0 {
+			logger.SetLevel(core.DEBUG)
+		} else {
+			logger.SetLevel(core.WARN)
+		}
+	}
+	wg.Wait()
+}
+
+// TestLoggerNamed tests hierarchical logger names
+func TestLoggerNamed(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: formatter.NewJSONFormatter(),
+	})
+	defer logger.Close()
+
+	db := logger.Named("db")
+	pool := db.Named("pool")
+	if pool.Name() != "db.pool" {
+		t.Errorf("Expected name db.pool, got %q", pool.Name())
+	}
+	if db.Named("").Name() != "db" {
+		t.Errorf("Named with an empty name should keep the parent name")
+	}
+
+	pool.Info("acquired")
+	logger.Info("root")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `"logger":"db.pool"`) {
+		t.Errorf("Expected logger name in %s", lines[0])
+	}
+	if strings.Contains(lines[1], `"logger"`) {
+		t.Errorf("Unnamed logger should not emit a logger key, got %s", lines[1])
+	}
+}
+
 // errorWriter is a mock writer that always returns an error
 type errorWriter struct{}
 
 func (ew *errorWriter) Write(p []byte) (n int, err error) {
 	return 0, os.ErrInvalid
-}
\ No newline at end of file
+}
+// recordingHook records fired entries and Close calls
+type recordingHook struct {
+	mu     sync.Mutex
+	fired  []string
+	closed int
+}
+
+func (h *recordingHook) Fire(entry *core.LogEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fired = append(h.fired, string(entry.Message))
+	return nil
+}
+
+func (h *recordingHook) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed++
+	return nil
+}
+
+// TestLoggerFatalFlushesAsync tests that FATAL drains the async queue before writing and exiting
+func TestLoggerFatalFlushesAsync(t *testing.T) {
+	var buf bytes.Buffer
+	exitCode := -1
+	h := &recordingHook{}
+	logger := New(LoggerConfig{
+		Level:                       core.INFO,
+		Output:                      &buf,
+		Formatter:                   &formatter.TextFormatter{ShowTimestamp: false},
+		AsyncLogging:                true,
+		AsyncWorkerCount:            1,
+		AsyncLogChannelBufferSize:   100,
+		DisablePerLogContextTimeout: true,
+		Hooks:                       []hook.Hook{h},
+	})
+	defer logger.Close()
+	// Keep the default ExitFunc in the config, so the hooks are closed as before os.Exit
+	logger.exitFunc = func(code int) { exitCode = code }
+
+	for i := 0; i < 20; i++ {
+		logger.Info("queued")
+	}
+	logger.Fatal("fatal message")
+
+	if exitCode != 1 {
+		t.Fatalf("Expected exit code 1, got %d", exitCode)
+	}
+	output := buf.String()
+	if strings.Count(output, "queued") != 20 {
+		t.Errorf("Expected all queued entries before exit, got %q", output)
+	}
+	if !strings.HasSuffix(strings.TrimSpace(output), "fatal message") {
+		t.Errorf("Expected the fatal entry to be written last, got %q", output)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed != 1 {
+		t.Errorf("Expected hooks to be closed once before exit, got %d", h.closed)
+	}
+	if len(h.fired) == 0 || h.fired[len(h.fired)-1] != "fatal message" {
+		t.Errorf("Expected the fatal entry to reach the hook before it was closed, got %v", h.fired)
+	}
+}
+
+// TestLoggerExitKeepsHooks tests that hooks stay open when FATAL or PANIC do not end the process
+func TestLoggerExitKeepsHooks(t *testing.T) {
+	h := &recordingHook{}
+	logger := New(LoggerConfig{
+		Level:             core.INFO,
+		Output:            &bytes.Buffer{},
+		Formatter:         &formatter.TextFormatter{ShowTimestamp: false},
+		Hooks:             []hook.Hook{h},
+		PanicOnPanicLevel: true,
+		ExitFunc:          func(int) {},
+	})
+	defer logger.Close()
+
+	logger.Fatal("first fatal")
+	func() {
+		defer func() { recover() }()
+		logger.Panic("recovered panic")
+	}()
+	logger.Fatal("second fatal")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed != 0 {
+		t.Errorf("Expected hooks to stay open, got %d closes", h.closed)
+	}
+	if got := strings.Join(h.fired, ","); got != "first fatal,recovered panic,second fatal" {
+		t.Errorf("Expected hooks to see every entry, got %v", h.fired)
+	}
+}
+
+// TestLoggerFatalFlushesBuffer tests that FATAL flushes the buffered writer
+func TestLoggerFatalFlushesBuffer(t *testing.T) {
+	output := &lockedBuffer{}
+	logger := New(LoggerConfig{
+		Level:         core.INFO,
+		Output:        output,
+		Formatter:     &formatter.TextFormatter{ShowTimestamp: false},
+		BufferSize:    100,
+		FlushInterval: time.Hour,
+		BatchSize:     100,
+		ExitFunc:      func(int) {},
+	})
+	defer logger.Close()
+
+	logger.Info("buffered")
+	logger.Fatal("fatal message")
+
+	got := output.String()
+	if !strings.Contains(got, "buffered") || !strings.Contains(got, "fatal message") {
+		t.Errorf("Expected buffered entries to be flushed before exit, got %q", got)
+	}
+}
+
+// TestLoggerExitFlushTimeout tests that a stuck writer does not block the exit
+func TestLoggerExitFlushTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var handled []error
+	var handledMu sync.Mutex
+	exited := false
+
+	logger := New(LoggerConfig{
+		Level:            core.INFO,
+		Output:           &blockingWriter{release: release},
+		Formatter:        &formatter.TextFormatter{ShowTimestamp: false},
+		BufferSize:       100,
+		FlushInterval:    time.Hour,
+		BatchSize:        100,
+		ExitFlushTimeout: 20 * time.Millisecond,
+		ExitFunc:         func(int) { exited = true },
+		ErrorHandler: func(err error) {
+			handledMu.Lock()
+			handled = append(handled, err)
+			handledMu.Unlock()
+		},
+	})
+
+	start := time.Now()
+	logger.Fatal("fatal message")
+	if !exited {
+		t.Fatal("Expected ExitFunc to be called")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Exit took %v, expected the flush deadline to apply", elapsed)
+	}
+	handledMu.Lock()
+	defer handledMu.Unlock()
+	if len(handled) == 0 {
+		t.Error("Expected the flush timeout to be reported")
+	}
+}
+
+// TestLoggerPanicOnPanicLevel tests the option to panic instead of exiting
+func TestLoggerPanicOnPanicLevel(t *testing.T) {
+	var buf bytes.Buffer
+	exited := false
+	logger := New(LoggerConfig{
+		Level:             core.INFO,
+		Output:            &buf,
+		Formatter:         &formatter.TextFormatter{ShowTimestamp: false},
+		PanicOnPanicLevel: true,
+		ExitFunc:          func(int) { exited = true },
+	})
+	defer logger.Close()
+
+	defer func() {
+		r := recover()
+		if r != "panic message" {
+			t.Errorf("Expected panic with the message, got %v", r)
+		}
+		if exited {
+			t.Error("ExitFunc should not be called when panicking")
+		}
+		if !strings.Contains(buf.String(), "panic message") {
+			t.Errorf("Expected the entry to be written before panicking, got %q", buf.String())
+		}
+	}()
+	logger.Panic("panic message")
+	t.Error("Panic should not return")
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// blockingWriter blocks every write until release is closed
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
+
+// syncRecorder is a buffer that records Sync calls
+type syncRecorder struct {
+	lockedBuffer
+	syncs atomic.Int32
+}
+
+func (s *syncRecorder) Sync() error {
+	s.syncs.Add(1)
+	return nil
+}
+
+// TestLoggerSync tests that Sync writes every accepted entry and keeps the logger usable
+func TestLoggerSync(t *testing.T) {
+	output := &syncRecorder{}
+	logger := New(LoggerConfig{
+		Level:                       core.INFO,
+		Output:                      output,
+		Formatter:                   &formatter.TextFormatter{ShowTimestamp: false},
+		AsyncLogging:                true,
+		AsyncWorkerCount:            2,
+		AsyncLogChannelBufferSize:   100,
+		DisablePerLogContextTimeout: true,
+		BufferSize:                  100,
+		FlushInterval:               time.Hour,
+		BatchSize:                   100,
+	})
+	defer logger.Close()
+
+	for round := 1; round <= 2; round++ {
+		for i := 0; i < 10; i++ {
+			logger.Info("checkpoint")
+		}
+		if err := logger.Sync(); err != nil {
+			t.Fatalf("Sync returned error: %v", err)
+		}
+		if got := strings.Count(output.String(), "checkpoint"); got != 10*round {
+			t.Errorf("Round %d: expected %d entries after Sync, got %d", round, 10*round, got)
+		}
+		if got := output.syncs.Load(); got != int32(round) {
+			t.Errorf("Round %d: expected %d output syncs, got %d", round, round, got)
+		}
+	}
+}
+
+// slowSyncRecorder is a syncRecorder whose writes take a while, so producers outpace it
+type slowSyncRecorder struct {
+	syncRecorder
+}
+
+func (s *slowSyncRecorder) Write(p []byte) (int, error) {
+	time.Sleep(100 * time.Microsecond)
+	return s.syncRecorder.Write(p)
+}
+
+// TestLoggerSyncUnderLoad tests that Sync returns while other goroutines keep logging
+func TestLoggerSyncUnderLoad(t *testing.T) {
+	output := &slowSyncRecorder{}
+	logger := New(LoggerConfig{
+		Level:                       core.INFO,
+		Output:                      output,
+		Formatter:                   &formatter.TextFormatter{ShowTimestamp: false},
+		AsyncLogging:                true,
+		AsyncWorkerCount:            2,
+		AsyncLogChannelBufferSize:   200,
+		DisablePerLogContextTimeout: true,
+		ErrorHandler:                func(error) {}, // Entries are dropped while the queue is full
+	})
+	defer logger.Close()
+
+	// Producers keep the queue from ever draining
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					logger.Info("load")
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	synced := make(chan error, 1)
+	go func() { synced <- logger.Sync() }()
+	select {
+	case err := <-synced:
+		if err != nil {
+			t.Fatalf("Sync returned error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sync did not return while other goroutines were logging")
+	}
+	if got := output.syncs.Load(); got != 1 {
+		t.Errorf("Expected the output to be synced once, got %d", got)
+	}
+}
+
+// TestLoggerSyncFile tests syncing a file output
+func TestLoggerSyncFile(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "sync-*.log")
+	if err != nil {
+		t.Fatalf("CreateTemp returned error: %v", err)
+	}
+	defer file.Close()
+
+	logger := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    file,
+		Formatter: &formatter.TextFormatter{ShowTimestamp: false},
+	})
+	logger.Info("on disk")
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil || !strings.Contains(string(content), "on disk") {
+		t.Errorf("Expected the entry in the file after Sync, got %q (%v)", content, err)
+	}
+
+	logger.Close()
+	if err := logger.Sync(); err != nil {
+		t.Errorf("Sync after Close should be a no-op, got %v", err)
+	}
+}
+
+// TestLoggerFlushContextDeadline tests that FlushContext gives up when its context is done
+func TestLoggerFlushContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	logger := New(LoggerConfig{
+		Level:         core.INFO,
+		Output:        &blockingWriter{release: release},
+		Formatter:     &formatter.TextFormatter{ShowTimestamp: false},
+		BufferSize:    100,
+		FlushInterval: time.Hour,
+		BatchSize:     100,
+	})
+
+	logger.Info("stuck")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := logger.FlushContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+// callerHook records the function names of entry callers
+type callerHook struct {
+	mu        sync.Mutex
+	functions []string
+}
+
+func (h *callerHook) Fire(entry *core.LogEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if entry.Caller != nil {
+		h.functions = append(h.functions, entry.Caller.Function)
+	}
+	return nil
+}
+
+func (h *callerHook) Close() error { return nil }
+
+// logThroughWrapper logs like a helper that wraps the logger
+func logThroughWrapper(l *Logger, message string) {
+	l.Info(message)
+}
+
+// TestLoggerAddCallerSkip tests reporting the caller of a wrapper
+func TestLoggerAddCallerSkip(t *testing.T) {
+	h := &callerHook{}
+	logger := New(LoggerConfig{
+		Level:      core.INFO,
+		Output:     io.Discard,
+		ShowCaller: true,
+		CallerDepth: 5, // Frames from the caller lookup to the caller of Info
+		Hooks:      []hook.Hook{h},
+		LevelRules: []LevelRule{{Function: "logThroughWrapper", Level: core.ERROR}},
+	})
+	defer logger.Close()
+
+	logThroughWrapper(logger, "reported as the wrapper")
+	logThroughWrapper(logger.AddCallerSkip(1), "reported as the test")
+
+	if len(h.functions) != 1 || h.functions[0] != "TestLoggerAddCallerSkip" {
+		t.Errorf("Expected only the skipped call to pass the wrapper's level rule, got callers %v", h.functions)
+	}
+}
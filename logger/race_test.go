@@ -0,0 +1,6 @@
+//go:build race
+
+package logger
+
+// raceEnabled reports whether the tests run under the race detector, which allocates on every call
+const raceEnabled = true
@@ -0,0 +1,219 @@
+package logger
+
+import (
+	"bytes"
+	"reflect"
+	"runtime"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/hook"
+	"github.com/Lunar-Chipter/mire/sampler"
+)
+
+// formatterRef holds a formatter so it can be replaced atomically
+type formatterRef struct {
+	formatter.Formatter
+}
+
+// samplerRef holds a Sampler so it can be replaced atomically
+type samplerRef struct {
+	sampler.Sampler
+	usesCaller bool // The sampler keys entries by call site, see sampler.CallerSampler
+}
+
+// sampledOut reports whether the sampler drops the current log call
+func (l *Logger) sampledOut() bool {
+	s := l.sampler.Load()
+	return s != nil && !s.ShouldLog()
+}
+
+// SetFormatter replaces the formatter of the logger output at runtime. Like the level,
+// the formatter is shared by the logger and all loggers derived from it.
+// LoggerConfig.MaskStringValue is applied to it. Sinks keep their own formatters.
+func (l *Logger) SetFormatter(f formatter.Formatter) {
+	l.setFormatter(f, l.Config.MaskStringValue)
+}
+
+// setFormatter stores f, or the default formatter if f is nil, with the given mask
+func (l *Logger) setFormatter(f formatter.Formatter, mask string) {
+	if f == nil {
+		f = &formatter.TextFormatter{TimestampFormat: DEFAULT_TIMESTAMP_FORMAT}
+	}
+	applyMask(f, mask)
+	l.formatter.Store(&formatterRef{f})
+}
+
+// SetSamplingRate changes the sampling rate at runtime so that every rate-th log call
+// is written. A rate of 1 or less disables sampling. The sampler is shared by the
+// logger and all loggers derived from it.
+func (l *Logger) SetSamplingRate(rate int) {
+	if rate <= 1 {
+		l.sampler.Store(nil)
+		return
+	}
+	l.sampler.Store(sampler.NewSamplingLogger(l, rate))
+}
+
+// SetSampler replaces the sampler of built entries at runtime, see LoggerConfig.Sampler.
+// A nil sampler disables it. The sampler is shared by the logger and all loggers
+// derived from it. A sampler.Releaser writes the entries it releases through l, and
+// the rates of a sampler.RateReporter are reported by the logger statistics.
+func (l *Logger) SetSampler(s sampler.Sampler) {
+	if s == nil {
+		l.entrySampler.Store(nil)
+		l.stats.setRates(nil)
+		return
+	}
+	if r, ok := s.(sampler.Releaser); ok {
+		r.SetRelease(l.writeSampled)
+	}
+	rates, _ := s.(sampler.RateReporter)
+	l.stats.setRates(rates)
+	cs, ok := s.(sampler.CallerSampler)
+	l.entrySampler.Store(&samplerRef{Sampler: s, usesCaller: ok && cs.UsesCaller()})
+}
+
+// samplerPC returns the program counter of the call site if the sampler keys entries
+// by call site, and 0 otherwise. A pc of 0 is looked up skip frames above the caller.
+func (l *Logger) samplerPC(pc uintptr, skip int) uintptr {
+	ref := l.entrySampler.Load()
+	if ref == nil || !ref.usesCaller {
+		return 0
+	}
+	if pc != 0 {
+		return pc
+	}
+	var pcs [1]uintptr
+	if runtime.Callers(skip+2+l.callerSkip, pcs[:]) > 0 {
+		return pcs[0]
+	}
+	return 0
+}
+
+// sampleEntry reports whether the sampler keeps the built entry. FATAL and PANIC
+// entries are always kept so their exit actions run; they are only passed to a
+// sampler.Releaser, so a tail sampler releases their trace while counting samplers
+// do not spend budget on them.
+func (l *Logger) sampleEntry(entry *core.LogEntry) bool {
+	ref := l.entrySampler.Load()
+	if ref == nil {
+		return true
+	}
+	if entry.Level >= core.FATAL {
+		if _, ok := ref.Sampler.(sampler.Releaser); ok {
+			ref.Sample(entry)
+		}
+		return true
+	}
+	return ref.Sample(entry)
+}
+
+// SetHooks replaces the hooks configured with LoggerConfig.Hooks for the logger and
+// all loggers derived from it. Hooks added with AddHook are kept. Replaced hooks that
+// are not in the new list are closed once no entry is being passed to them.
+func (l *Logger) SetHooks(hooks ...hook.Hook) {
+	l.mu.Lock()
+	replaced := *l.configHooks
+	*l.configHooks = append([]hook.Hook(nil), hooks...)
+	l.mu.Unlock()
+
+	for _, h := range replaced {
+		if containsHook(hooks, h) {
+			continue
+		}
+		if err := h.Close(); err != nil {
+			l.handleError(newErrorf("error closing replaced hook: %v", err))
+		}
+	}
+}
+
+// containsHook reports whether hooks contains h
+func containsHook(hooks []hook.Hook, h hook.Hook) bool {
+	if !reflect.TypeOf(h).Comparable() {
+		return false
+	}
+	for _, other := range hooks {
+		if reflect.TypeOf(other) == reflect.TypeOf(h) && other == h {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload applies the settings of c that can safely change at runtime to the logger
+// and all loggers derived from it: the level, level rules, sampling, the formatter and
+// its masking, the formatters and levels of sinks with the same name, and the hooks
+// of LoggerConfig.Hooks. Outputs, buffering, rotation and async settings are kept,
+// and sinks that the logger was not created with are ignored. The sampler is only
+// replaced if c.Sampler is set; SetSampler(nil) removes it.
+// A config with invalid level rules is rejected without changing anything.
+// A successful reload is reported as a *ConfigReloadEvent, see LoggerConfig.OnConfigChange.
+func (l *Logger) Reload(c LoggerConfig) error {
+	return l.reload(c, "")
+}
+
+// reload implements Reload, naming the source of the config in the event
+func (l *Logger) reload(c LoggerConfig, source string) error {
+	rules, err := newLevelRuleSet(c.LevelRules)
+	if err != nil {
+		return newErrorf("invalid level rules: %v", err)
+	}
+
+	l.SetLevel(c.Level)
+	l.levelRules.Store(rules)
+	if c.EnableSampling {
+		l.SetSamplingRate(c.SamplingRate)
+	} else {
+		l.SetSamplingRate(0)
+	}
+	if c.Sampler != nil {
+		l.SetSampler(c.Sampler)
+	}
+	l.setFormatter(c.Formatter, c.MaskStringValue)
+	for i, sc := range c.Sinks {
+		if s := l.findSink(sinkName(i, sc)); s != nil {
+			s.level.Store(int32(sc.Level))
+			s.formatter.Store(&formatterRef{sinkFormatter(sc)})
+		}
+	}
+	l.SetHooks(c.Hooks...)
+
+	l.configChanged(&ConfigReloadEvent{Source: source, Level: c.Level})
+	return nil
+}
+
+// findSink returns the sink with the given name, or nil
+func (l *Logger) findSink(name string) *sink {
+	for _, s := range l.sinks {
+		if s.name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// ConfigReloadEvent is reported when Reload or a ConfigWatcher applied a new
+// configuration, see LoggerConfig.OnConfigChange
+type ConfigReloadEvent struct {
+	Source string     // Config file the settings were read from, empty for Logger.Reload
+	Level  core.Level // Logger level after the reload
+}
+
+// AppendError implements the ErrorAppender interface for ConfigReloadEvent
+func (e *ConfigReloadEvent) AppendError(buf *bytes.Buffer) {
+	buf.WriteString("logger configuration reloaded")
+	if e.Source != "" {
+		buf.WriteString(" from ")
+		buf.WriteString(e.Source)
+	}
+	buf.WriteString(", level ")
+	buf.Write(e.Level.Bytes())
+}
+
+// Error returns the event description
+func (e *ConfigReloadEvent) Error() string {
+	var buf bytes.Buffer
+	e.AppendError(&buf)
+	return buf.String()
+}
@@ -0,0 +1,456 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/hook"
+	"github.com/Lunar-Chipter/mire/sampler"
+	"github.com/Lunar-Chipter/mire/util"
+)
+
+// eventRecorder collects the errors and events passed to the error handler
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []error
+}
+
+func (r *eventRecorder) handle(err error) {
+	r.mu.Lock()
+	r.events = append(r.events, err)
+	r.mu.Unlock()
+}
+
+func (r *eventRecorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var parts []string
+	for _, err := range r.events {
+		parts = append(parts, err.Error())
+	}
+	return strings.Join(parts, "\n")
+}
+
+// closeCountingHook records fired entries and how often it was closed
+type closeCountingHook struct {
+	mu     sync.Mutex
+	fired  int
+	closed int
+}
+
+func (h *closeCountingHook) Fire(entry *core.LogEntry) error {
+	h.mu.Lock()
+	h.fired++
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *closeCountingHook) Close() error {
+	h.mu.Lock()
+	h.closed++
+	h.mu.Unlock()
+	return nil
+}
+
+// TestLoggerSetFormatter tests replacing the formatter for the whole logger family
+func TestLoggerSetFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{Level: core.INFO, Output: &buf, Formatter: &formatter.TextFormatter{}})
+	defer l.Close()
+	child := l.With(core.String("k", "v"))
+
+	l.SetFormatter(formatter.NewJSONFormatter())
+	child.Info("after")
+
+	if !strings.Contains(buf.String(), `"message":"after"`) {
+		t.Errorf("Expected JSON output from the derived logger, got %q", buf.String())
+	}
+}
+
+// TestLoggerSetSamplingRate tests enabling and disabling sampling at runtime
+func TestLoggerSetSamplingRate(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{Level: core.INFO, Output: &buf, Formatter: &formatter.TextFormatter{}})
+	defer l.Close()
+	child := l.Named("child")
+
+	l.SetSamplingRate(4)
+	for i := 0; i < 8; i++ {
+		child.Info("sampled")
+	}
+	if got := strings.Count(buf.String(), "sampled"); got != 2 {
+		t.Errorf("Expected 2 of 8 entries with rate 4, got %d", got)
+	}
+
+	buf.Reset()
+	l.SetSamplingRate(0)
+	for i := 0; i < 8; i++ {
+		child.Info("all")
+	}
+	if got := strings.Count(buf.String(), "all"); got != 8 {
+		t.Errorf("Expected every entry without sampling, got %d", got)
+	}
+}
+
+// TestLoggerSetHooks tests replacing the configured hooks
+func TestLoggerSetHooks(t *testing.T) {
+	kept, replaced, added, manual := &closeCountingHook{}, &closeCountingHook{}, &closeCountingHook{}, &closeCountingHook{}
+	l := New(LoggerConfig{Level: core.INFO, Output: &bytes.Buffer{}, Hooks: []hook.Hook{kept, replaced}})
+	defer l.Close()
+	l.AddHook(manual)
+	child := l.Named("child")
+
+	l.SetHooks(kept, added)
+	child.Info("entry")
+
+	if replaced.closed != 1 || kept.closed != 0 {
+		t.Errorf("Expected only the replaced hook to be closed, got %d and %d", replaced.closed, kept.closed)
+	}
+	if replaced.fired != 0 || kept.fired != 1 || added.fired != 1 {
+		t.Errorf("Unexpected fired counts replaced=%d kept=%d added=%d", replaced.fired, kept.fired, added.fired)
+	}
+	if manual.fired != 1 || manual.closed != 0 {
+		t.Errorf("Expected AddHook hooks to be kept, got fired=%d closed=%d", manual.fired, manual.closed)
+	}
+}
+
+// TestLoggerReload tests applying a new config at runtime
+func TestLoggerReload(t *testing.T) {
+	rec := &eventRecorder{}
+	console, file := &lockedBuffer{}, &lockedBuffer{}
+	l := New(LoggerConfig{
+		Level:        core.WARN,
+		ErrorHandler: rec.handle,
+		Sinks: []SinkConfig{
+			{Name: "console", Output: console, Synchronous: true, Formatter: &formatter.TextFormatter{}},
+			{Name: "file", Output: file, Synchronous: true, Level: core.ERROR, Formatter: &formatter.TextFormatter{}},
+		},
+	})
+	defer l.Close()
+
+	err := l.Reload(LoggerConfig{
+		Level:      core.DEBUG,
+		LevelRules: []LevelRule{{Component: "noisy", Level: core.ERROR}},
+		Sinks: []SinkConfig{
+			{Name: "console", Formatter: formatter.NewJSONFormatter()},
+			{Name: "file", Level: core.DEBUG, Formatter: &formatter.TextFormatter{}},
+			{Name: "new", Output: &lockedBuffer{}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+
+	l.Debug("debug entry")
+	l.Named("noisy").Warn("filtered")
+
+	if !strings.Contains(console.String(), `"message":"debug entry"`) {
+		t.Errorf("Expected the console sink to use JSON, got %q", console.String())
+	}
+	if !strings.Contains(file.String(), "debug entry") {
+		t.Errorf("Expected the file sink level to be lowered, got %q", file.String())
+	}
+	if strings.Contains(console.String(), "filtered") {
+		t.Error("Expected the level rules to be applied")
+	}
+	if got := rec.String(); !strings.Contains(got, "log level changed from WARN to DEBUG") || !strings.Contains(got, "logger configuration reloaded, level DEBUG") {
+		t.Errorf("Expected the reload to be reported, got %q", got)
+	}
+}
+
+// TestLoggerReloadReported tests that a reload is written to the error output without callbacks
+func TestLoggerReloadReported(t *testing.T) {
+	var errOut bytes.Buffer
+	l := New(LoggerConfig{Level: core.INFO, Output: &bytes.Buffer{}, ErrorOutput: &errOut})
+	defer l.Close()
+
+	if err := l.Reload(LoggerConfig{Level: core.INFO}); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	if want := "logger: logger configuration reloaded, level INFO\n"; errOut.String() != want {
+		t.Errorf("Expected %q on the error output, got %q", want, errOut.String())
+	}
+}
+
+// TestLoggerReloadRejected tests that an invalid config changes nothing
+func TestLoggerReloadRejected(t *testing.T) {
+	l := New(LoggerConfig{Level: core.WARN, Output: &bytes.Buffer{}})
+	defer l.Close()
+
+	if err := l.Reload(LoggerConfig{Level: core.DEBUG, LevelRules: []LevelRule{{Level: core.INFO}}}); err == nil {
+		t.Fatal("Expected an error for a rule without a pattern")
+	}
+	if l.GetLevel() != core.WARN {
+		t.Errorf("Expected the level to be unchanged, got %v", l.GetLevel())
+	}
+}
+
+// TestLoggerReloadKeepsSampler tests that a config without a sampler keeps the current one
+func TestLoggerReloadKeepsSampler(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{Level: core.INFO, Output: &buf, Formatter: &formatter.TextFormatter{}, Sampler: dropSampler{"noise"}})
+	defer l.Close()
+
+	if err := l.Reload(LoggerConfig{Level: core.INFO}); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	l.Info("noise")
+	if buf.Len() != 0 {
+		t.Errorf("Expected the sampler to be kept, got %q", buf.String())
+	}
+
+	if err := l.Reload(LoggerConfig{Level: core.INFO, Sampler: dropSampler{"other"}}); err != nil {
+		t.Fatalf("Reload returned error: %v", err)
+	}
+	l.Info("noise")
+	l.Info("other")
+	if want := "[INFO] noise\n"; buf.String() != want {
+		t.Errorf("Expected the sampler to be replaced, got %q", buf.String())
+	}
+}
+
+// dropSampler keeps only the entries whose message does not contain drop
+type dropSampler struct {
+	drop string
+}
+
+func (s dropSampler) Sample(entry *core.LogEntry) bool {
+	return !strings.Contains(string(entry.Message), s.drop)
+}
+
+// TestLoggerSampler tests sampling built entries, also through derived loggers
+func TestLoggerSampler(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: &formatter.TextFormatter{},
+		Sampler:   dropSampler{"noise"},
+		ExitFunc:  func(int) {},
+	})
+	defer l.Close()
+	child := l.Named("child")
+
+	child.Info("noise")
+	child.InfoEvent().Msg("noise from an event")
+	child.Info("signal")
+	l.Fatal("noise at FATAL")
+
+	got := buf.String()
+	if strings.Count(got, "noise") != 1 || !strings.Contains(got, "[FATAL] noise at FATAL") || !strings.Contains(got, "signal") {
+		t.Errorf("Expected only the signal and the FATAL entry, got %q", got)
+	}
+
+	buf.Reset()
+	l.SetSampler(nil)
+	child.Info("noise")
+	if !strings.Contains(buf.String(), "noise") {
+		t.Error("Expected SetSampler(nil) to disable the sampler")
+	}
+}
+
+// TestLoggerRateLimiter tests that suppressed counts reach the formatted output
+func TestLoggerRateLimiter(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: &formatter.TextFormatter{},
+		Sampler:   sampler.NewRateLimiter(sampler.RateLimiterConfig{Default: sampler.RateLimit{Rate: 10, Burst: 1}}),
+	})
+	defer l.Close()
+
+	// One call site, the sixth call after the bucket refilled
+	for i := 0; i < 6; i++ {
+		if i == 5 {
+			time.Sleep(150 * time.Millisecond)
+		}
+		l.Info("retry")
+	}
+
+	want := "[INFO] retry\n[INFO] retry {suppressed=4}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// retryA and retryB log the same message from two call sites
+func retryA(l *Logger) { l.Info("retry") }
+func retryB(l *Logger) { l.InfoEvent().Msg("retry") }
+
+// TestLoggerRateLimiterByCaller tests that each call site gets its own bucket
+func TestLoggerRateLimiterByCaller(t *testing.T) {
+	for name, async := range map[string]bool{"sync": false, "async": true} {
+		t.Run(name, func(t *testing.T) {
+			var buf lockedBuffer
+			limiter := sampler.NewRateLimiter(sampler.RateLimiterConfig{Default: sampler.RateLimit{Rate: 0.001, Burst: 1}})
+			l := New(LoggerConfig{
+				Level:                     core.INFO,
+				Output:                    &buf,
+				Formatter:                 &formatter.TextFormatter{},
+				ShowCaller:                true,
+				AsyncLogging:              async,
+				AsyncWorkerCount:          1,
+				AsyncLogChannelBufferSize: 100,
+				Sampler:                   limiter,
+			})
+
+			for i := 0; i < 3; i++ {
+				retryA(l)
+				retryB(l)
+				slog.New(NewSlogHandler(l)).Info("retry")
+			}
+			l.Close()
+
+			if got := strings.Count(buf.String(), "retry"); got != 3 {
+				t.Errorf("Expected one entry per call site, got %d: %q", got, buf.String())
+			}
+			if limiter.Len() != 3 {
+				t.Errorf("Expected 3 buckets, got %d", limiter.Len())
+			}
+		})
+	}
+}
+
+// TestLoggerTailSampler tests that released entries are written before the error
+func TestLoggerTailSampler(t *testing.T) {
+	var buf bytes.Buffer
+	tail := sampler.NewTailSampler(sampler.TailSamplerConfig{})
+	l := New(LoggerConfig{Level: core.DEBUG, Output: &buf, Formatter: &formatter.TextFormatter{}, Sampler: sampler.Chain(tail)})
+	defer l.Close()
+
+	failed := util.WithTraceID(context.Background(), "failed")
+	ok := util.WithTraceID(context.Background(), "ok")
+	l.DebugC(failed, "loading")
+	l.DebugC(ok, "loading fine")
+	l.InfoC(ok, "done")
+	l.ErrorC(failed, "query failed")
+
+	want := "[INFO] done\n[DEBUG] loading\n[ERROR] query failed\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if stats := tail.Stats(); stats.FlushedTraces != 1 || stats.PendingEntries != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestLoggerTailSamplerFatal tests that a FATAL entry releases the entries held for its trace
+func TestLoggerTailSamplerFatal(t *testing.T) {
+	var buf bytes.Buffer
+	tail := sampler.NewTailSampler(sampler.TailSamplerConfig{})
+	l := New(LoggerConfig{Level: core.DEBUG, Output: &buf, Formatter: &formatter.TextFormatter{}, Sampler: tail, ExitFunc: func(int) {}})
+	defer l.Close()
+
+	ctx := util.WithTraceID(context.Background(), "fatal")
+	l.DebugC(ctx, "loading")
+	l.FatalC(ctx, "giving up")
+
+	want := "[DEBUG] loading\n[FATAL] giving up\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if stats := tail.Stats(); stats.FlushedTraces != 1 || stats.PendingEntries != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestLoggerFatalNotCounted tests that counting samplers do not see FATAL entries
+func TestLoggerFatalNotCounted(t *testing.T) {
+	var buf bytes.Buffer
+	var drops int
+	ticks := sampler.NewTickSampler(sampler.TickSamplerConfig{
+		First:  1,
+		OnDrop: func(*core.LogEntry, uint64) { drops++ },
+	})
+	l := New(LoggerConfig{Level: core.INFO, Output: &buf, Formatter: &formatter.TextFormatter{}, Sampler: ticks, ExitFunc: func(int) {}})
+	defer l.Close()
+
+	for i := 0; i < 3; i++ {
+		l.Fatal("fatal")
+	}
+	if got := strings.Count(buf.String(), "fatal"); got != 3 || drops != 0 {
+		t.Errorf("Expected 3 FATAL entries and no drops, got %d entries and %d drops", got, drops)
+	}
+}
+
+// TestLoggerHashSampler tests that loggers sampling at the same probability keep the same traces
+func TestLoggerHashSampler(t *testing.T) {
+	var first, second bytes.Buffer
+	newLogger := func(buf *bytes.Buffer) *Logger {
+		return New(LoggerConfig{
+			Level:     core.INFO,
+			Output:    buf,
+			Formatter: &formatter.TextFormatter{},
+			Sampler:   sampler.NewHashSampler(sampler.HashSamplerConfig{Probability: 0.5}),
+		})
+	}
+	a, b := newLogger(&first), newLogger(&second)
+	defer a.Close()
+	defer b.Close()
+
+	for i := 0; i < 100; i++ {
+		ctx := util.WithTraceID(context.Background(), "trace-"+strconv.Itoa(i))
+		a.InfoC(ctx, "request ", i)
+		b.InfoC(ctx, "request ", i)
+	}
+
+	if first.String() != second.String() {
+		t.Error("Expected both loggers to keep the same traces")
+	}
+	if n := strings.Count(first.String(), "\n"); n < 30 || n > 70 {
+		t.Errorf("Expected about half of the traces, got %d", n)
+	}
+}
+
+// TestLoggerAdaptiveSamplerStats tests reporting the adaptive rate in the statistics
+func TestLoggerAdaptiveSamplerStats(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    &buf,
+		Formatter: &formatter.TextFormatter{},
+		Sampler: sampler.NewAdaptiveSampler(sampler.AdaptiveSamplerConfig{
+			Default: sampler.AdaptiveBudget{EntriesPerSecond: 100},
+			Window:  50 * time.Millisecond,
+		}),
+	})
+	defer l.Close()
+
+	if rates := l.Stats().SamplingRates(); rates[core.INFO] != 1 {
+		t.Errorf("Expected the INFO rate 1 before logging, got %v", rates)
+	}
+	for i := 0; i < 200; i++ {
+		l.Info("burst")
+	}
+	if n := strings.Count(buf.String(), "\n"); n != 5 {
+		t.Errorf("Expected the window to be capped at 5 entries, got %d", n)
+	}
+	time.Sleep(60 * time.Millisecond)
+	l.Info("after")
+
+	rate := l.Stats().SamplingRates()[core.INFO]
+	if rate <= 0 || rate >= 1 {
+		t.Errorf("Expected the INFO rate between 0 and 1, got %v", rate)
+	}
+	rates, ok := l.Named("child").Stats().GetStats()["sampling_rates"].(map[string]float64)
+	if !ok || rates[core.INFO.String()] != rate {
+		t.Errorf("Expected the rate in the statistics of derived loggers, got %v", rates)
+	}
+
+	l.SetSampler(nil)
+	if rates := l.Stats().SamplingRates(); rates != nil {
+		t.Errorf("Expected no rates without a sampler, got %v", rates)
+	}
+	if _, ok := l.Stats().GetStats()["sampling_rates"]; ok {
+		t.Error("Expected no sampling rates in the statistics")
+	}
+}
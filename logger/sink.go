@@ -0,0 +1,188 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/util"
+	"github.com/Lunar-Chipter/mire/writer"
+)
+
+// SinkConfig configures one output of a logger that fans out to several sinks.
+// Every sink has its own formatter, level, buffer and masking, and errors in one sink
+// are reported to the error handler without affecting the others.
+type SinkConfig struct {
+	Name            string              // Name used in error messages, defaults to "sink <index>"
+	Output          io.Writer           // Destination of the sink
+	Formatter       formatter.Formatter // Formatter of the sink, defaults to a text formatter
+	Level           core.Level          // Minimum level written to the sink, checked after the logger level
+	Loggers         []string            // Logger name patterns routed to the sink, e.g. "db.*"; empty routes every logger
+	Synchronous     bool                // Write in the logging goroutine instead of through a buffer. A slow synchronous sink delays the other sinks.
+	BufferSize      int                 // Number of entries the sink buffers, defaults to DEFAULT_BUFFER_SIZE. A full buffer drops entries instead of blocking.
+	FlushInterval   time.Duration       // Interval to flush the buffer, defaults to DEFAULT_FLUSH_INTERVAL
+	BatchSize       int                 // Size of batches written by the buffer
+	BatchTimeout    time.Duration       // Timeout for batched writes
+	MaskStringValue string              // String used to mask sensitive fields in this sink
+}
+
+// sink is a configured output of the logger
+type sink struct {
+	name      string
+	level     atomic.Int32 // Minimum level, replaceable by Logger.Reload
+	loggers   []string
+	formatter atomic.Pointer[formatterRef] // Replaceable by Logger.Reload
+	out       io.Writer                    // Final destination, synced by FlushContext
+	buffer    *writer.BufferedWriter       // Nil for synchronous sinks
+	mu        sync.Mutex                   // Serializes writes of a synchronous sink
+}
+
+// setupSinks creates the sinks configured in LoggerConfig.Sinks
+func (l *Logger) setupSinks() {
+	l.sinks = make([]*sink, 0, len(l.Config.Sinks))
+	for i, sc := range l.Config.Sinks {
+		name := sinkName(i, sc)
+		if sc.Output == nil {
+			l.handleError(newErrorf("sink %s has no output", name))
+			continue
+		}
+
+		s := &sink{
+			name:    name,
+			loggers: sc.Loggers,
+			out:     sc.Output,
+		}
+		s.level.Store(int32(sc.Level))
+		s.formatter.Store(&formatterRef{sinkFormatter(sc)})
+		if !sc.Synchronous {
+			bufferSize := sc.BufferSize
+			if bufferSize <= 0 {
+				bufferSize = DEFAULT_BUFFER_SIZE
+			}
+			flushInterval := sc.FlushInterval
+			if flushInterval <= 0 {
+				flushInterval = DEFAULT_FLUSH_INTERVAL
+			}
+			errorHandler := func(err error) {
+				l.handleError(newErrorf("sink %s: %v", name, err))
+			}
+			s.buffer = writer.NewBufferedWriter(sc.Output, bufferSize, flushInterval, errorHandler, sc.BatchSize, sc.BatchTimeout)
+		}
+		l.sinks = append(l.sinks, s)
+	}
+}
+
+// sinkName returns the name of the i-th configured sink
+func sinkName(i int, sc SinkConfig) string {
+	if sc.Name != "" {
+		return sc.Name
+	}
+	return "sink " + strconv.Itoa(i)
+}
+
+// sinkFormatter returns the formatter of a sink config with its masking applied
+func sinkFormatter(sc SinkConfig) formatter.Formatter {
+	f := sc.Formatter
+	if f == nil {
+		f = &formatter.TextFormatter{TimestampFormat: DEFAULT_TIMESTAMP_FORMAT}
+	}
+	applyMask(f, sc.MaskStringValue)
+	return f
+}
+
+// writeSinks formats and writes the entry to every sink that accepts it and
+// returns the total number of bytes written
+func (l *Logger) writeSinks(entry *core.LogEntry) int {
+	buf := util.GetBufferFromPool()
+	defer util.PutBufferToPool(buf)
+
+	written := 0
+	for _, s := range l.sinks {
+		if !s.accepts(entry) {
+			continue
+		}
+		buf.Reset()
+		n, err := s.write(buf, entry)
+		if err != nil {
+			l.handleError(newErrorf("sink %s: %v", s.name, err))
+			continue
+		}
+		written += n
+	}
+	return written
+}
+
+// accepts reports whether the entry's level and logger name are routed to the sink
+func (s *sink) accepts(entry *core.LogEntry) bool {
+	if entry.Level < core.Level(s.level.Load()) {
+		return false
+	}
+	if len(s.loggers) == 0 {
+		return true
+	}
+	name := core.BytesToString(entry.LoggerName)
+	for _, pattern := range s.loggers {
+		if matchGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// write formats the entry into buf and writes it to the sink.
+// A panicking formatter or writer is reported as an error.
+func (s *sink) write(buf *bytes.Buffer, entry *core.LogEntry) (n int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newErrorf("recovered from panic: %v", r)
+		}
+	}()
+
+	if err := s.formatter.Load().Format(buf, entry); err != nil {
+		return 0, err
+	}
+	if s.buffer != nil {
+		return s.buffer.Write(buf.Bytes())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out.Write(buf.Bytes())
+}
+
+// flushSinks flushes the sink buffers and syncs the sink outputs.
+// Every sink is flushed even if another one fails; the first error is returned.
+func (l *Logger) flushSinks(ctx context.Context) error {
+	var firstErr error
+	for _, s := range l.sinks {
+		if s.buffer != nil {
+			if err := s.buffer.Flush(ctx); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+		}
+		if err := syncWriter(s.out); err != nil && firstErr == nil {
+			firstErr = newErrorf("sink %s: %v", s.name, err)
+		}
+	}
+	return firstErr
+}
+
+// closeSinks closes the sink buffers, which flushes them
+func (l *Logger) closeSinks() {
+	for _, s := range l.sinks {
+		if s.buffer == nil {
+			continue
+		}
+		if err := s.buffer.Close(); err != nil {
+			l.handleError(newErrorf("error closing sink %s: %v", s.name, err))
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// panickingFormatter panics on every entry
+type panickingFormatter struct{}
+
+func (panickingFormatter) Format(buf *bytes.Buffer, entry *core.LogEntry) error {
+	panic("formatter bug")
+}
+
+// TestSinksFanOut tests per-sink level and formatter
+func TestSinksFanOut(t *testing.T) {
+	console, file := &lockedBuffer{}, &lockedBuffer{}
+	l := New(LoggerConfig{
+		Level: core.DEBUG,
+		Sinks: []SinkConfig{
+			{Name: "console", Output: console, Level: core.INFO, Synchronous: true,
+				Formatter: &formatter.TextFormatter{ShowTimestamp: false}},
+			{Name: "file", Output: file, Level: core.DEBUG,
+				Formatter: formatter.NewJSONFormatter()},
+		},
+	})
+	defer l.Close()
+
+	l.Debug("debug entry")
+	l.Info("info entry")
+	if err := l.Sync(); err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+
+	if got := console.String(); strings.Contains(got, "debug entry") || !strings.Contains(got, "[INFO] info entry") {
+		t.Errorf("Console sink should only get INFO as text, got %q", got)
+	}
+	got := file.String()
+	for _, want := range []string{`"message":"debug entry"`, `"message":"info entry"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %s in file sink output %q", want, got)
+		}
+	}
+}
+
+// TestSinksLoggerRouting tests routing entries by logger name
+func TestSinksLoggerRouting(t *testing.T) {
+	all, db := &lockedBuffer{}, &lockedBuffer{}
+	l := New(LoggerConfig{
+		Level: core.INFO,
+		Sinks: []SinkConfig{
+			{Output: all, Synchronous: true, Formatter: &formatter.TextFormatter{ShowTimestamp: false}},
+			{Output: db, Synchronous: true, Loggers: []string{"db", "db.*"}, Formatter: &formatter.TextFormatter{ShowTimestamp: false}},
+		},
+	})
+	defer l.Close()
+
+	l.Info("root")
+	l.Named("db").Info("db")
+	l.Named("db").Named("pool").Info("pool")
+	l.Named("http").Info("http")
+
+	if got := strings.Count(all.String(), "\n"); got != 4 {
+		t.Errorf("Expected every entry in the unrouted sink, got %q", all.String())
+	}
+	got := db.String()
+	if !strings.Contains(got, "logger=db db") || !strings.Contains(got, "logger=db.pool pool") {
+		t.Errorf("Expected db entries in the routed sink, got %q", got)
+	}
+	if strings.Contains(got, "root") || strings.Contains(got, "http") {
+		t.Errorf("Unexpected entries in the routed sink: %q", got)
+	}
+}
+
+// TestSinksErrorIsolation tests that a failing sink does not affect the others
+func TestSinksErrorIsolation(t *testing.T) {
+	good := &lockedBuffer{}
+	var mu sync.Mutex
+	var handled []string
+	l := New(LoggerConfig{
+		Level: core.INFO,
+		ErrorHandler: func(err error) {
+			mu.Lock()
+			handled = append(handled, err.Error())
+			mu.Unlock()
+		},
+		Sinks: []SinkConfig{
+			{Name: "broken", Output: failingWriter{}, Synchronous: true},
+			{Name: "buggy", Output: &lockedBuffer{}, Synchronous: true, Formatter: panickingFormatter{}},
+			{Name: "good", Output: good, Synchronous: true, Formatter: &formatter.TextFormatter{ShowTimestamp: false}},
+		},
+	})
+	defer l.Close()
+
+	l.Info("still delivered")
+
+	if !strings.Contains(good.String(), "still delivered") {
+		t.Errorf("Expected the good sink to receive the entry, got %q", good.String())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	joined := strings.Join(handled, "\n")
+	for _, want := range []string{"sink broken: disk full", "sink buggy: recovered from panic: formatter bug"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected error %q, got %q", want, joined)
+		}
+	}
+}
+
+// TestSinksSlowSinkDoesNotBlock tests that a stuck buffered sink does not block the others
+func TestSinksSlowSinkDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	fast := &lockedBuffer{}
+	l := New(LoggerConfig{
+		Level:        core.INFO,
+		ErrorHandler: func(error) {},
+		Sinks: []SinkConfig{
+			{Name: "stuck", Output: &blockingWriter{release: release}, BufferSize: 4, FlushInterval: time.Millisecond},
+			{Name: "fast", Output: fast, Synchronous: true, Formatter: &formatter.TextFormatter{ShowTimestamp: false}},
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			l.Info("entry")
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Logging blocked on the stuck sink")
+	}
+	if got := strings.Count(fast.String(), "entry"); got != 100 {
+		t.Errorf("Expected 100 entries in the fast sink, got %d", got)
+	}
+
+	close(release)
+	l.Close()
+}
+
+// TestSinksMask tests per-sink masking settings
+func TestSinksMask(t *testing.T) {
+	text := &formatter.TextFormatter{}
+	json := formatter.NewJSONFormatter()
+	logfmt := formatter.NewLogfmtFormatter()
+	l := New(LoggerConfig{
+		Sinks: []SinkConfig{
+			{Output: &lockedBuffer{}, Formatter: text, MaskStringValue: "***"},
+			{Output: &lockedBuffer{}, Formatter: json},
+			{Output: &lockedBuffer{}, Formatter: logfmt, MaskStringValue: "<hidden>"},
+		},
+	})
+	defer l.Close()
+
+	if string(text.MaskStringBytes) != "***" {
+		t.Errorf("Expected sink mask ***, got %q", text.MaskStringBytes)
+	}
+	if string(json.MaskStringBytes) != "[MASKED]" {
+		t.Errorf("Expected default mask, got %q", json.MaskStringBytes)
+	}
+	if string(logfmt.MaskStringBytes) != "<hidden>" {
+		t.Errorf("Expected sink mask <hidden>, got %q", logfmt.MaskStringBytes)
+	}
+}
@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// SlogHandler is a slog.Handler that routes records into a Logger's pipeline,
+// so libraries that only know log/slog share the logger's formatter, hooks,
+// async and buffered writers.
+type SlogHandler struct {
+	logger *Logger // Logger that receives the records
+	prefix string  // Key prefix built from WithGroup calls, e.g. "http.request."
+}
+
+// NewSlogHandler creates a slog.Handler backed by the given logger
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{logger: l}
+}
+
+// Enabled reports whether the logger would emit a record at the given slog level
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if h.logger.closed.Load() {
+		return false
+	}
+	return SlogLevelToLevel(level) >= h.logger.minEnabledLevel()
+}
+
+// Handle converts the record attributes to typed fields and logs the record.
+// Level rules are matched against the call site recorded in the record, and the
+// entry carries the record time unless it is zero.
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	level := SlogLevelToLevel(r.Level)
+	if h.logger.closed.Load() || !h.logger.levelEnabled(level, r.PC, 0) {
+		return nil
+	}
+
+	var fields []core.Field
+	if r.NumAttrs() > 0 {
+		fields = make([]core.Field, 0, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			fields = appendSlogAttr(fields, h.prefix, a)
+			return true
+		})
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// The record time is when the slog call was made, which may differ from now
+	h.logger.dispatch(ctx, level, core.StringToBytes(r.Message), nil, fields, r.PC, r.Time)
+	return nil
+}
+
+// WithAttrs returns a handler whose logger carries the given attributes as default fields
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := make([]core.Field, 0, len(attrs))
+	for _, a := range attrs {
+		fields = appendSlogAttr(fields, h.prefix, a)
+	}
+	return &SlogHandler{
+		logger: h.logger.With(fields...),
+		prefix: h.prefix,
+	}
+}
+
+// WithGroup returns a handler that prefixes the keys of subsequent attributes with the group name
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{
+		logger: h.logger,
+		prefix: h.prefix + name + ".",
+	}
+}
+
+// SlogLevelToLevel maps a slog level to the closest core.Level.
+// Levels between the standard slog levels map to the level below them, except
+// that INFO+2 and INFO+3 map to NOTICE. Levels above ERROR stay at ERROR so that
+// slog callers can never trigger FATAL or PANIC handling.
+func SlogLevelToLevel(level slog.Level) core.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return core.TRACE
+	case level < slog.LevelInfo:
+		return core.DEBUG
+	case level < slog.LevelInfo+2:
+		return core.INFO
+	case level < slog.LevelWarn:
+		return core.NOTICE
+	case level < slog.LevelError:
+		return core.WARN
+	default:
+		return core.ERROR
+	}
+}
+
+// appendSlogAttr flattens an attribute into typed fields, joining group names with dots
+func appendSlogAttr(fields []core.Field, prefix string, a slog.Attr) []core.Field {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return fields // Empty attributes are ignored per the slog.Handler contract
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if a.Key != "" {
+			groupPrefix = prefix + a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendSlogAttr(fields, groupPrefix, ga)
+		}
+		return fields
+	}
+
+	return append(fields, slogValueToField(prefix+a.Key, a.Value))
+}
+
+// slogValueToField converts a resolved slog value to a typed field
+func slogValueToField(key string, v slog.Value) core.Field {
+	switch v.Kind() {
+	case slog.KindString:
+		return core.String(key, v.String())
+	case slog.KindInt64:
+		return core.Int64(key, v.Int64())
+	case slog.KindUint64:
+		return core.Uint64(key, v.Uint64())
+	case slog.KindFloat64:
+		return core.Float64(key, v.Float64())
+	case slog.KindBool:
+		return core.Bool(key, v.Bool())
+	case slog.KindDuration:
+		return core.Duration(key, v.Duration())
+	case slog.KindTime:
+		return core.Time(key, v.Time())
+	default:
+		return core.Any(key, v.Any())
+	}
+}
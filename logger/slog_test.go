@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// newSlogTestLogger creates a logger writing plain text into buf
+func newSlogTestLogger(buf *bytes.Buffer, level core.Level) *Logger {
+	return New(LoggerConfig{
+		Level:  level,
+		Output: buf,
+		Formatter: &formatter.TextFormatter{
+			EnableColors:  false,
+			ShowTimestamp: false,
+			ShowCaller:    false,
+		},
+	})
+}
+
+// TestSlogLevelToLevel tests the mapping of slog levels to core levels
+func TestSlogLevelToLevel(t *testing.T) {
+	tests := []struct {
+		in   slog.Level
+		want core.Level
+	}{
+		{slog.LevelDebug - 4, core.TRACE},
+		{slog.LevelDebug, core.DEBUG},
+		{slog.LevelInfo, core.INFO},
+		{slog.LevelInfo + 2, core.NOTICE},
+		{slog.LevelWarn, core.WARN},
+		{slog.LevelError, core.ERROR},
+		{slog.LevelError + 8, core.ERROR},
+	}
+
+	for _, tt := range tests {
+		if got := SlogLevelToLevel(tt.in); got != tt.want {
+			t.Errorf("SlogLevelToLevel(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestSlogHandlerRoutesRecords tests that slog records reach the logger output
+func TestSlogHandlerRoutesRecords(t *testing.T) {
+	var buf bytes.Buffer
+	l := newSlogTestLogger(&buf, core.INFO)
+	defer l.Close()
+
+	sl := slog.New(NewSlogHandler(l))
+	sl.Info("user logged in", "user", "alice", "attempts", 3, "ok", true)
+	sl.Debug("filtered out")
+
+	output := buf.String()
+	if !strings.Contains(output, "[INFO]") || !strings.Contains(output, "user logged in") {
+		t.Errorf("Expected INFO record in output, got %q", output)
+	}
+	for _, want := range []string{"user=alice", "attempts=3", "ok=true"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got %q", want, output)
+		}
+	}
+	if strings.Contains(output, "filtered out") {
+		t.Error("Debug record should be filtered at INFO level")
+	}
+}
+
+// TestSlogHandlerGroupsAndAttrs tests WithGroup key prefixes and WithAttrs fields
+func TestSlogHandlerGroupsAndAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	l := newSlogTestLogger(&buf, core.INFO)
+	defer l.Close()
+
+	sl := slog.New(NewSlogHandler(l)).With("service", "api").WithGroup("http")
+	sl.Warn("slow request",
+		slog.Group("request", slog.String("method", "GET")),
+		slog.Int("status", 200),
+		slog.Any("err", errors.New("timeout")),
+	)
+
+	output := buf.String()
+	for _, want := range []string{"service=api", "http.request.method=GET", "http.status=200", "http.err=timeout", "[WARN]"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got %q", want, output)
+		}
+	}
+}
+
+// TestSlogHandlerEnabled tests that Enabled follows the logger level and closed state
+func TestSlogHandlerEnabled(t *testing.T) {
+	var buf bytes.Buffer
+	l := newSlogTestLogger(&buf, core.WARN)
+	h := NewSlogHandler(l)
+
+	if h.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("INFO should not be enabled at WARN level")
+	}
+	if !h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("ERROR should be enabled at WARN level")
+	}
+
+	l.Close()
+	if h.Enabled(context.Background(), slog.LevelError) {
+		t.Error("No level should be enabled after the logger is closed")
+	}
+}
+
+// TestSlogHandlerRecordTime tests that entries carry the record time when it is set
+func TestSlogHandlerRecordTime(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(LoggerConfig{Level: core.INFO, Output: &buf, Formatter: &formatter.JSONFormatter{TimestampFormat: time.RFC3339}})
+	defer l.Close()
+	h := NewSlogHandler(l)
+
+	recorded := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	if err := h.Handle(context.Background(), slog.NewRecord(recorded, slog.LevelInfo, "replayed", 0)); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, `"2024-05-01T10:00:00Z"`) {
+		t.Errorf("Expected the record time, got %q", got)
+	}
+
+	buf.Reset()
+	h.Handle(context.Background(), slog.NewRecord(time.Time{}, slog.LevelInfo, "now", 0))
+	if got := buf.String(); strings.Contains(got, "2024-05-01") || strings.Contains(got, "0001-01-01") {
+		t.Errorf("Expected the current time for a zero record time, got %q", got)
+	}
+}
@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// stdLogPrefixKey is the field that holds the prefix of a standard library logger
+const stdLogPrefixKey = "prefix"
+
+// LineWriter is an io.Writer that logs every line written to it as a separate entry.
+// Incomplete lines are kept until the rest of the line is written or the writer is closed.
+// It is safe for concurrent use.
+type LineWriter struct {
+	logger *Logger
+	level  core.Level
+	std    *log.Logger // Standard logger whose prefix and flags are stripped from each line, if any
+
+	mu      sync.Mutex
+	pending []byte // Incomplete line carried over between writes
+}
+
+// Writer returns an io.Writer that logs each line written to it at the given level.
+// It can be used for third-party libraries or as the Stdout/Stderr of an exec.Cmd.
+// Close the writer to log a trailing line without a newline.
+func (l *Logger) Writer(level core.Level) *LineWriter {
+	return &LineWriter{logger: l, level: level}
+}
+
+// RedirectStdLog points the output of the standard library's default logger at l.
+// The prefix, date, time and file:line added by the log package are parsed from each line:
+// the file and line become the caller of the entry and a prefix is kept as the "prefix" field.
+// It returns a function that restores the previous output.
+func RedirectStdLog(l *Logger, level core.Level) func() {
+	std := log.Default()
+	previous := std.Writer()
+	std.SetOutput(&LineWriter{logger: l, level: level, std: std})
+	return func() {
+		std.SetOutput(previous)
+	}
+}
+
+// NewStdLog returns a standard library logger that writes to l at the given level,
+// for APIs such as http.Server.ErrorLog that need a *log.Logger
+func NewStdLog(l *Logger, level core.Level) *log.Logger {
+	w := &LineWriter{logger: l, level: level}
+	std := log.New(w, "", 0)
+	w.std = std
+	return std
+}
+
+// Write logs every complete line in p. It always reports len(p) bytes written.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := len(p)
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.pending = append(w.pending, p...)
+			break
+		}
+		line := p[:i]
+		if len(w.pending) > 0 {
+			w.pending = append(w.pending, line...)
+			line = w.pending
+		}
+		w.logLine(line)
+		w.pending = w.pending[:0]
+		p = p[i+1:]
+	}
+	return n, nil
+}
+
+// Close logs a pending incomplete line. The writer can still be used afterwards.
+func (w *LineWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.pending) > 0 {
+		w.logLine(w.pending)
+		w.pending = w.pending[:0]
+	}
+	return nil
+}
+
+var _ io.WriteCloser = (*LineWriter)(nil)
+
+// logLine logs a single line, applying the same checks as Logger.log
+func (w *LineWriter) logLine(line []byte) {
+	if n := len(line); n > 0 && line[n-1] == '\r' {
+		line = line[:n-1]
+	}
+	if len(line) == 0 {
+		return
+	}
+
+	l := w.logger
+	if l.closed.Load() {
+		return
+	}
+	// The call site is the code that called Write
+	if !l.levelEnabled(w.level, 0, logCallerSkip) {
+		return
+	}
+	if l.sampledOut() {
+		return
+	}
+
+	var prefix string
+	var file []byte
+	var fileLine int
+	if w.std != nil {
+		prefix = w.std.Prefix()
+		line, file, fileLine = parseStdLogLine(line, prefix, w.std.Flags())
+	}
+
+	// The caller reuses p after Write returns, the async logger keeps the entry
+	message := line
+	if l.asyncLogger != nil {
+		message = append([]byte(nil), line...)
+	}
+
+	entry := l.buildEntryByte(context.Background(), w.level, message, nil, nil)
+	if trimmed := bytes.TrimSpace(core.StringToBytes(prefix)); len(trimmed) > 0 {
+		addTypedField(entry, core.ByteString(stdLogPrefixKey, trimmed))
+	}
+	if file != nil {
+		if entry.Caller == nil {
+			entry.Caller = core.GetCallerInfoFromPool()
+		}
+		entry.Caller.File = filepath.Base(string(file))
+		entry.Caller.Line = fileLine
+		entry.Caller.Function = ""
+		entry.Caller.Package = ""
+	}
+	l.dispatchEntry(entry)
+}
+
+// parseStdLogLine strips the prefix, date, time and file:line that a log.Logger with
+// the given prefix and flags writes before the message. Parts that do not match the
+// expected layout are left in the message.
+func parseStdLogLine(line []byte, prefix string, flags int) (message, file []byte, fileLine int) {
+	if flags&log.Lmsgprefix == 0 {
+		line = bytes.TrimPrefix(line, core.StringToBytes(prefix))
+	}
+	if flags&log.Ldate != 0 && matchDigitLayout(line, "0000/00/00 ") {
+		line = line[len("0000/00/00 "):]
+	}
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		layout := "00:00:00 "
+		if flags&log.Lmicroseconds != 0 {
+			layout = "00:00:00.000000 "
+		}
+		if matchDigitLayout(line, layout) {
+			line = line[len(layout):]
+		}
+	}
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if end := bytes.Index(line, []byte(": ")); end > 0 {
+			location := line[:end]
+			if colon := bytes.LastIndexByte(location, ':'); colon > 0 {
+				if n, err := strconv.Atoi(string(location[colon+1:])); err == nil {
+					file, fileLine = location[:colon], n
+					line = line[end+2:]
+				}
+			}
+		}
+	}
+	if flags&log.Lmsgprefix != 0 {
+		line = bytes.TrimPrefix(line, core.StringToBytes(prefix))
+	}
+	return line, file, fileLine
+}
+
+// matchDigitLayout reports whether b starts with layout, where '0' in layout matches any digit
+func matchDigitLayout(b []byte, layout string) bool {
+	if len(b) < len(layout) {
+		return false
+	}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == '0' {
+			if b[i] < '0' || b[i] > '9' {
+				return false
+			}
+		} else if b[i] != layout[i] {
+			return false
+		}
+	}
+	return true
+}
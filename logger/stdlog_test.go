@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+)
+
+// newStdLogTestLogger creates a JSON logger with caller output writing to buf
+func newStdLogTestLogger(buf *bytes.Buffer) *Logger {
+	jsonFormatter := formatter.NewJSONFormatter()
+	jsonFormatter.ShowCaller = true
+	return New(LoggerConfig{
+		Level:     core.INFO,
+		Output:    buf,
+		Formatter: jsonFormatter,
+	})
+}
+
+// TestLineWriter tests splitting written data into entries
+func TestLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := newStdLogTestLogger(&buf)
+	defer l.Close()
+
+	w := l.Writer(core.WARN)
+	fmt.Fprint(w, "first line\r\nsecond ")
+	fmt.Fprint(w, "line\n\nthird")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries before Close, got %d: %s", len(lines), buf.String())
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	lines = strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 entries after Close, got %d: %s", len(lines), buf.String())
+	}
+	for i, want := range []string{"first line", "second line", "third"} {
+		if !strings.Contains(lines[i], `"message":"`+want+`"`) || !strings.Contains(lines[i], `"level_name":"WARN"`) {
+			t.Errorf("Expected WARN entry %q, got %s", want, lines[i])
+		}
+	}
+
+	// Lines below the logger level are dropped
+	buf.Reset()
+	fmt.Fprintln(l.Writer(core.DEBUG), "hidden")
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output for a disabled level, got %s", buf.String())
+	}
+}
+
+// TestRedirectStdLog tests parsing the standard logger's prefix and flags
+func TestRedirectStdLog(t *testing.T) {
+	var buf bytes.Buffer
+	l := newStdLogTestLogger(&buf)
+	defer l.Close()
+
+	previousOutput, previousPrefix, previousFlags := log.Writer(), log.Prefix(), log.Flags()
+	defer log.SetOutput(previousOutput)
+	defer log.SetPrefix(previousPrefix)
+	defer log.SetFlags(previousFlags)
+
+	var original bytes.Buffer
+	log.SetOutput(&original)
+	restore := RedirectStdLog(l, core.ERROR)
+
+	log.SetPrefix("[lib] ")
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+	log.Print("connection reset")
+
+	log.SetFlags(log.Ltime | log.Lmsgprefix)
+	log.Print("with message prefix")
+
+	restore()
+	log.Print("restored")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %s", len(lines), buf.String())
+	}
+	for _, want := range []string{`"message":"connection reset"`, `"level_name":"ERROR"`, `"prefix":"[lib]"`, `"caller":"stdlog_test.go:`} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("Expected %s in %s", want, lines[0])
+		}
+	}
+	if !strings.Contains(lines[1], `"message":"with message prefix"`) {
+		t.Errorf("Expected stripped message in %s", lines[1])
+	}
+	if !strings.Contains(original.String(), "restored") || strings.Contains(buf.String(), "restored") {
+		t.Errorf("Restore should bring back the previous output, got %q", original.String())
+	}
+}
+
+// TestNewStdLog tests a standard logger that writes to mire
+func TestNewStdLog(t *testing.T) {
+	var buf bytes.Buffer
+	l := newStdLogTestLogger(&buf)
+	defer l.Close()
+
+	std := NewStdLog(l.Named("http"), core.WARN)
+	std.Printf("http: TLS handshake error from %s", "10.0.0.1")
+
+	output := buf.String()
+	for _, want := range []string{`"logger":"http"`, `"message":"http: TLS handshake error from 10.0.0.1"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %s in %s", want, output)
+		}
+	}
+}
+
+// TestParseStdLogLine tests stripping the standard log header
+func TestParseStdLogLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		prefix   string
+		flags    int
+		message  string
+		file     string
+		fileLine int
+	}{
+		{"msg", "", 0, "msg", "", 0},
+		{"2024/01/02 03:04:05 msg", "", log.LstdFlags, "msg", "", 0},
+		{"p: 03:04:05.123456 /src/a.go:12: msg", "p: ", log.Lmicroseconds | log.Llongfile, "msg", "/src/a.go", 12},
+		{"03:04:05 p: msg", "p: ", log.Ltime | log.Lmsgprefix, "msg", "", 0},
+		{"not a date msg", "", log.Ldate, "not a date msg", "", 0},
+		{"a.go:x: msg", "", log.Lshortfile, "a.go:x: msg", "", 0},
+	}
+
+	for _, tt := range tests {
+		message, file, fileLine := parseStdLogLine([]byte(tt.line), tt.prefix, tt.flags)
+		if string(message) != tt.message || string(file) != tt.file || fileLine != tt.fileLine {
+			t.Errorf("parseStdLogLine(%q) = %q, %q, %d, want %q, %q, %d",
+				tt.line, message, file, fileLine, tt.message, tt.file, tt.fileLine)
+		}
+	}
+}
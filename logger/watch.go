@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DEFAULT_CONFIG_POLL_INTERVAL is how often a ConfigWatcher checks its file by default
+const DEFAULT_CONFIG_POLL_INTERVAL = time.Second
+
+// ConfigWatcher reloads a logger from a JSON config file, see LoadConfig, when the
+// file changes or the process receives SIGHUP. Only the settings that Logger.Reload
+// can change at runtime are applied. A file that fails to parse or validate is
+// reported to the error handler and the logger keeps its current settings.
+type ConfigWatcher struct {
+	logger   *Logger
+	path     string
+	interval time.Duration
+	signals  chan os.Signal
+	done     chan struct{}
+	once     sync.Once
+	wg       sync.WaitGroup
+	mu       sync.Mutex // Serializes reloads
+	modTime  time.Time  // Modification time of the file at the last check
+	size     int64      // Size of the file at the last check
+}
+
+// WatchConfig starts watching the config file at path for l. The file is polled
+// every interval, or every DEFAULT_CONFIG_POLL_INTERVAL if interval is not positive.
+// The current contents are assumed to be applied already, e.g. by LoadConfig.
+func WatchConfig(l *Logger, path string, interval time.Duration) (*ConfigWatcher, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = DEFAULT_CONFIG_POLL_INTERVAL
+	}
+
+	w := &ConfigWatcher{
+		logger:   l,
+		path:     path,
+		interval: interval,
+		signals:  make(chan os.Signal, 1),
+		done:     make(chan struct{}),
+		modTime:  info.ModTime(),
+		size:     info.Size(),
+	}
+	signal.Notify(w.signals, syscall.SIGHUP)
+
+	w.wg.Add(1)
+	go w.run()
+	return w, nil
+}
+
+// run polls the file and handles SIGHUP until the watcher is closed
+func (w *ConfigWatcher) run() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.signals:
+			w.Reload()
+		case <-ticker.C:
+			if w.changed() {
+				w.Reload()
+			}
+		}
+	}
+}
+
+// changed reports whether the file was modified since the last check. A missing
+// file, e.g. while an editor replaces it, counts as unchanged.
+func (w *ConfigWatcher) changed() bool {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false
+	}
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+	return true
+}
+
+// Reload re-reads the file, applies the MIRE_* environment overrides and reloads the
+// logger. A rejected config is reported to the error handler and returned.
+func (w *ConfigWatcher) Reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.reload()
+	if err != nil {
+		err = newErrorf("rejected logger config %s: %v", w.path, err)
+		w.logger.handleError(err)
+	}
+	return err
+}
+
+func (w *ConfigWatcher) reload() error {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	fc, err := ParseFileConfig(data)
+	if err != nil {
+		return err
+	}
+	if err := fc.ApplyEnv(os.LookupEnv); err != nil {
+		return err
+	}
+	c, err := fc.buildReload()
+	if err != nil {
+		return err
+	}
+	if err := w.logger.reload(c, w.path); err != nil {
+		for _, h := range c.Hooks {
+			h.Close()
+		}
+		return err
+	}
+	return nil
+}
+
+// Close stops watching. It does not close the logger.
+func (w *ConfigWatcher) Close() {
+	w.once.Do(func() {
+		signal.Stop(w.signals)
+		close(w.done)
+		w.wg.Wait()
+	})
+}
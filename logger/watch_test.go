@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// writeConfigFile writes a config file and moves its modification time forward so
+// that every write is seen as a change
+func writeConfigFile(t *testing.T, path, doc string, mtime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitFor polls cond until it holds or the deadline passes
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestConfigWatcherFileChange tests reloading when the file changes
+func TestConfigWatcherFileChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mire.json")
+	start := time.Now().Add(-time.Hour)
+	writeConfigFile(t, path, `{"level": "warn", "output": "discard"}`, start)
+
+	rec := &eventRecorder{}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ErrorHandler = rec.handle
+	l := New(cfg)
+	defer l.Close()
+
+	w, err := WatchConfig(l, path, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchConfig returned error: %v", err)
+	}
+	defer w.Close()
+
+	writeConfigFile(t, path, `{"level": "debug", "sampling": {"enabled": true, "rate": 5}}`, start.Add(time.Minute))
+	waitFor(t, "the level change", func() bool { return l.GetLevel() == core.DEBUG })
+	if l.sampler.Load() == nil {
+		t.Error("Expected sampling to be enabled")
+	}
+
+	writeConfigFile(t, path, `{"level": "trace", "formatter": "xml"}`, start.Add(2*time.Minute))
+	waitFor(t, "the rejection", func() bool { return strings.Contains(rec.String(), "rejected logger config") })
+	if l.GetLevel() != core.DEBUG {
+		t.Errorf("Expected the rejected config not to change the level, got %v", l.GetLevel())
+	}
+	if !strings.Contains(rec.String(), "unknown formatter xml") {
+		t.Errorf("Expected the reason of the rejection, got %q", rec.String())
+	}
+}
+
+// TestConfigWatcherSIGHUP tests reloading on SIGHUP
+func TestConfigWatcherSIGHUP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mire.json")
+	mtime := time.Now().Add(-time.Hour)
+	writeConfigFile(t, path, `{"level": "warn"}`, mtime)
+
+	l := New(LoggerConfig{Level: core.WARN, Output: &lockedBuffer{}, ErrorHandler: func(error) {}})
+	defer l.Close()
+	w, err := WatchConfig(l, path, time.Hour)
+	if err != nil {
+		t.Fatalf("WatchConfig returned error: %v", err)
+	}
+	defer w.Close()
+
+	// Same modification time and size, so only the signal triggers the reload
+	writeConfigFile(t, path, `{"level": "info"}`, mtime)
+	w.signals <- syscall.SIGHUP
+	waitFor(t, "the level change", func() bool { return l.GetLevel() == core.INFO })
+}
+
+// TestWatchConfigMissingFile tests watching a file that does not exist
+func TestWatchConfigMissingFile(t *testing.T) {
+	l := New(LoggerConfig{Output: &lockedBuffer{}})
+	defer l.Close()
+	if _, err := WatchConfig(l, filepath.Join(t.TempDir(), "missing.json"), 0); !os.IsNotExist(err) {
+		t.Errorf("Expected a not-exist error, got %v", err)
+	}
+}
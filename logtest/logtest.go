@@ -0,0 +1,182 @@
+// Package logtest provides a logger for tests that records the entries it logs
+// instead of writing bytes, so tests can assert on levels, messages and fields
+// without parsing formatter output.
+//
+//	l, logs := logtest.New(core.DEBUG)
+//	l.InfoFields("user created", core.String("user", "ana"))
+//	logs.FilterMessage("created").FilterField("user", "ana").AssertCount(t, 1)
+package logtest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/logger"
+)
+
+// SINK_NAME is the name of the recording sink added to the logger
+const SINK_NAME = "logtest"
+
+// New creates a logger that records the entries of level and above. FATAL and PANIC
+// entries are recorded without exiting the process.
+func New(level core.Level) (*logger.Logger, *ObservedLogs) {
+	return NewWithConfig(logger.LoggerConfig{Level: level})
+}
+
+// NewWithConfig creates a logger from config that records its entries. Output and
+// Sinks of config are replaced by the recording sink, and ExitFunc defaults to a
+// function that does not exit so FATAL entries can be asserted.
+func NewWithConfig(config logger.LoggerConfig) (*logger.Logger, *ObservedLogs) {
+	logs := &ObservedLogs{}
+	config.Output = io.Discard
+	config.Sinks = []logger.SinkConfig{{
+		Name:        SINK_NAME,
+		Output:      io.Discard,
+		Formatter:   recorder{logs},
+		Level:       core.TRACE,
+		Synchronous: true,
+	}}
+	if config.ExitFunc == nil {
+		config.ExitFunc = func(int) {}
+	}
+	return logger.New(config), logs
+}
+
+// recorder is a formatter that records a copy of every entry and writes nothing
+type recorder struct {
+	logs *ObservedLogs
+}
+
+// Format records a copy of entry, the logger reuses the entry afterwards
+func (r recorder) Format(buf *bytes.Buffer, entry *core.LogEntry) error {
+	r.logs.add(*entry.Clone())
+	return nil
+}
+
+// ObservedLogs holds recorded entries in the order they were logged. The filter
+// methods return snapshots that can be chained; the logger keeps recording into
+// the ObservedLogs returned by New.
+type ObservedLogs struct {
+	mu      sync.Mutex
+	entries []core.LogEntry
+}
+
+func (o *ObservedLogs) add(entry core.LogEntry) {
+	o.mu.Lock()
+	o.entries = append(o.entries, entry)
+	o.mu.Unlock()
+}
+
+// Len returns the number of recorded entries
+func (o *ObservedLogs) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.entries)
+}
+
+// All returns the recorded entries
+func (o *ObservedLogs) All() []core.LogEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]core.LogEntry(nil), o.entries...)
+}
+
+// TakeAll returns the recorded entries and removes them
+func (o *ObservedLogs) TakeAll() []core.LogEntry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	entries := o.entries
+	o.entries = nil
+	return entries
+}
+
+// Messages returns the messages of the recorded entries
+func (o *ObservedLogs) Messages() []string {
+	entries := o.All()
+	messages := make([]string, len(entries))
+	for i := range entries {
+		messages[i] = string(entries[i].Message)
+	}
+	return messages
+}
+
+// Filter returns the entries for which keep returns true
+func (o *ObservedLogs) Filter(keep func(*core.LogEntry) bool) *ObservedLogs {
+	filtered := &ObservedLogs{}
+	for _, entry := range o.All() {
+		if keep(&entry) {
+			filtered.entries = append(filtered.entries, entry)
+		}
+	}
+	return filtered
+}
+
+// FilterLevel returns the entries logged at level
+func (o *ObservedLogs) FilterLevel(level core.Level) *ObservedLogs {
+	return o.Filter(func(e *core.LogEntry) bool { return e.Level == level })
+}
+
+// FilterMessage returns the entries whose message contains substr
+func (o *ObservedLogs) FilterMessage(substr string) *ObservedLogs {
+	return o.Filter(func(e *core.LogEntry) bool { return bytes.Contains(e.Message, []byte(substr)) })
+}
+
+// FilterField returns the entries with a field key whose value prints like value,
+// so core.Int fields match int values. Both typed fields and the fields of
+// WithFields and the context are searched.
+func (o *ObservedLogs) FilterField(key string, value interface{}) *ObservedLogs {
+	want := valueString(value)
+	return o.Filter(func(e *core.LogEntry) bool {
+		got, ok := FieldValue(e, key)
+		return ok && valueString(got) == want
+	})
+}
+
+// Last returns the last n entries, or all of them if there are fewer
+func (o *ObservedLogs) Last(n int) *ObservedLogs {
+	entries := o.All()
+	if n < 0 {
+		n = 0
+	}
+	if n < len(entries) {
+		entries = entries[len(entries)-n:]
+	}
+	return &ObservedLogs{entries: entries}
+}
+
+// AssertCount reports a test error unless exactly n entries were recorded
+func (o *ObservedLogs) AssertCount(t testing.TB, n int) bool {
+	t.Helper()
+	if got := o.Len(); got != n {
+		t.Errorf("Expected %d log entries, got %d: %q", n, got, o.Messages())
+		return false
+	}
+	return true
+}
+
+// FieldValue returns the value of the field key of entry. Typed fields are searched
+// last to first so the most recently added field wins; fields stored as bytes are
+// returned as strings.
+func FieldValue(entry *core.LogEntry, key string) (interface{}, bool) {
+	for i := len(entry.TypedFields) - 1; i >= 0; i-- {
+		if f := entry.TypedFields[i]; f.Key == key {
+			return f.Value(), true
+		}
+	}
+	if v, ok := entry.Fields[key]; ok {
+		return string(v), true
+	}
+	return nil, false
+}
+
+// valueString returns the printed form of a field value used for comparisons
+func valueString(v interface{}) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
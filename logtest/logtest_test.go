@@ -0,0 +1,151 @@
+package logtest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/logger"
+)
+
+// TestObserverRecordsEntries tests that entries are recorded with their metadata
+func TestObserverRecordsEntries(t *testing.T) {
+	l, logs := New(core.INFO)
+	defer l.Close()
+
+	l.Debug("below the level")
+	l.Named("db").With(core.String("table", "users")).InfoFields("query", core.Int("rows", 3))
+	l.WithFields(map[string]interface{}{"user": "ana"}).WithError(errors.New("boom")).Error("failed")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d: %q", len(entries), logs.Messages())
+	}
+	query := entries[0]
+	if query.Level != core.INFO || string(query.Message) != "query" || string(query.LoggerName) != "db" {
+		t.Errorf("Unexpected first entry %v %q %q", query.Level, query.Message, query.LoggerName)
+	}
+	if v, ok := FieldValue(&query, "rows"); !ok || v != int64(3) {
+		t.Errorf("Expected the typed field rows=3, got %v", v)
+	}
+	if v, ok := FieldValue(&query, "table"); !ok || v != "users" {
+		t.Errorf("Expected the logger field table=users, got %v", v)
+	}
+	failed := entries[1]
+	if failed.Error == nil || failed.Error.Error() != "boom" {
+		t.Errorf("Expected the error to be recorded, got %v", failed.Error)
+	}
+	if v, _ := FieldValue(&failed, "user"); v != "ana" {
+		t.Errorf("Expected the map field user=ana, got %v", v)
+	}
+}
+
+// TestObserverEntriesAreCopies tests that recorded entries survive pool reuse
+func TestObserverEntriesAreCopies(t *testing.T) {
+	l, logs := New(core.INFO)
+	defer l.Close()
+
+	l.InfoFields("first", core.String("n", "1"))
+	for i := 0; i < 100; i++ {
+		l.InfoFields("other", core.String("n", "x"))
+	}
+
+	first := logs.All()[0]
+	if string(first.Message) != "first" {
+		t.Errorf("Expected the first message to be kept, got %q", first.Message)
+	}
+	if v, _ := FieldValue(&first, "n"); v != "1" {
+		t.Errorf("Expected the first field to be kept, got %v", v)
+	}
+}
+
+// TestObserverFilters tests the query API
+func TestObserverFilters(t *testing.T) {
+	l, logs := New(core.TRACE)
+	defer l.Close()
+
+	for i := 0; i < 5; i++ {
+		l.InfoFields("request served", core.Int("status", 200+i))
+	}
+	l.WarnFields("request slow", core.Int("status", 200))
+	l.Error("request failed")
+
+	logs.FilterLevel(core.INFO).AssertCount(t, 5)
+	logs.FilterMessage("request").AssertCount(t, 7)
+	logs.FilterField("status", 200).AssertCount(t, 2)
+	logs.FilterLevel(core.INFO).FilterField("status", 200).AssertCount(t, 1)
+	logs.FilterField("missing", "").AssertCount(t, 0)
+
+	last := logs.Last(2).Messages()
+	if strings.Join(last, ",") != "request slow,request failed" {
+		t.Errorf("Unexpected last entries %q", last)
+	}
+	logs.Last(100).AssertCount(t, 7)
+
+	if taken := logs.TakeAll(); len(taken) != 7 || logs.Len() != 0 {
+		t.Errorf("Expected TakeAll to return and clear 7 entries, got %d and %d left", len(taken), logs.Len())
+	}
+}
+
+// TestObserverAssertCountFailure tests the failure report of AssertCount
+func TestObserverAssertCountFailure(t *testing.T) {
+	l, logs := New(core.INFO)
+	defer l.Close()
+	l.Info("only entry")
+
+	ft := &fakeTB{TB: t}
+	if logs.AssertCount(ft, 2) {
+		t.Error("Expected AssertCount to fail")
+	}
+	if !strings.Contains(ft.msg, "Expected 2 log entries, got 1") || !strings.Contains(ft.msg, "only entry") {
+		t.Errorf("Unexpected failure message %q", ft.msg)
+	}
+}
+
+// TestObserverFatalDoesNotExit tests that FATAL entries are recorded without exiting
+func TestObserverFatalDoesNotExit(t *testing.T) {
+	exits := 0
+	l, logs := NewWithConfig(logger.LoggerConfig{Level: core.INFO, ExitFunc: func(int) { exits++ }})
+	defer l.Close()
+
+	l.Fatal("fatal entry")
+
+	logs.FilterLevel(core.FATAL).AssertCount(t, 1)
+	if exits != 1 {
+		t.Errorf("Expected the configured exit function to be called once, got %d", exits)
+	}
+}
+
+// TestObserverConcurrent tests recording from several goroutines
+func TestObserverConcurrent(t *testing.T) {
+	l, logs := New(core.INFO)
+	defer l.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Info("entry")
+			}
+		}()
+	}
+	wg.Wait()
+	logs.AssertCount(t, 200)
+}
+
+// fakeTB captures the errors reported through testing.TB
+type fakeTB struct {
+	testing.TB
+	msg string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.msg += fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,107 @@
+package logtest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/hook"
+	"github.com/Lunar-Chipter/mire/logger"
+)
+
+// TBConfig configures a logger created by NewTBWithConfig
+type TBConfig struct {
+	logger.LoggerConfig      // Output and Sinks are replaced; Formatter defaults to a text formatter without timestamps
+	FailOnError         bool // Mark the test as failed when an ERROR or higher entry is logged
+}
+
+// NewTB creates a logger that writes the entries of level and above through t.Log,
+// so they are attributed to the test and only shown when it fails or runs with -v.
+// Entries logged after the test finished are dropped and reported to the error
+// handler instead of panicking.
+func NewTB(t testing.TB, level core.Level) *logger.Logger {
+	return NewTBWithConfig(t, TBConfig{LoggerConfig: logger.LoggerConfig{Level: level}})
+}
+
+// NewTBWithConfig creates a logger like NewTB from config. ExitFunc defaults to a
+// function that does not exit, so FATAL entries do not end the test binary.
+func NewTBWithConfig(t testing.TB, config TBConfig) *logger.Logger {
+	tl := &testLog{t: t, name: t.Name()}
+	t.Cleanup(tl.finish)
+
+	c := config.LoggerConfig
+	f := c.Formatter
+	if f == nil {
+		f = &formatter.TextFormatter{}
+	}
+	c.Output = io.Discard
+	c.Sinks = []logger.SinkConfig{{
+		Name:            SINK_NAME,
+		Output:          tl,
+		Formatter:       f,
+		Level:           core.TRACE,
+		Synchronous:     true,
+		MaskStringValue: c.MaskStringValue,
+	}}
+	if c.ExitFunc == nil {
+		c.ExitFunc = func(int) {}
+	}
+	if config.FailOnError {
+		c.Hooks = append(append([]hook.Hook(nil), c.Hooks...), failHook{tl})
+	}
+	return logger.New(c)
+}
+
+// testLog forwards formatted entries to a test until it finishes
+type testLog struct {
+	mu   sync.Mutex // Keeps t from being used while finish runs
+	t    testing.TB
+	name string
+	done bool
+}
+
+// Write logs p through t.Log without the trailing newline
+func (tl *testLog) Write(p []byte) (int, error) {
+	line := strings.TrimSuffix(string(p), "\n")
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if tl.done {
+		return 0, fmt.Errorf("dropped entry logged after %s finished: %s", tl.name, line)
+	}
+	tl.t.Log(line)
+	return len(p), nil
+}
+
+// fail marks the test as failed if it is still running
+func (tl *testLog) fail() {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	if !tl.done {
+		tl.t.Fail()
+	}
+}
+
+// finish stops forwarding, it runs as a cleanup of the test
+func (tl *testLog) finish() {
+	tl.mu.Lock()
+	tl.done = true
+	tl.mu.Unlock()
+}
+
+// failHook fails the test for entries of level ERROR and above
+type failHook struct {
+	tl *testLog
+}
+
+func (h failHook) Fire(entry *core.LogEntry) error {
+	if entry.Level >= core.ERROR {
+		h.tl.fail()
+	}
+	return nil
+}
+
+func (h failHook) Close() error { return nil }
@@ -0,0 +1,150 @@
+package logtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/logger"
+)
+
+// recordingTB records the calls a logger makes on a test
+type recordingTB struct {
+	testing.TB
+	mu       sync.Mutex
+	lines    []string
+	failed   bool
+	cleanups []func()
+}
+
+func (r *recordingTB) Name() string { return "TestRecorded" }
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Log(args ...interface{}) {
+	r.mu.Lock()
+	r.lines = append(r.lines, fmt.Sprint(args...))
+	r.mu.Unlock()
+}
+
+func (r *recordingTB) Fail() {
+	r.mu.Lock()
+	r.failed = true
+	r.mu.Unlock()
+}
+
+func (r *recordingTB) Cleanup(f func()) { r.cleanups = append(r.cleanups, f) }
+
+// finish runs the cleanups like the testing package does at the end of a test
+func (r *recordingTB) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+// TestTBLogsThroughTest tests that entries are written through t.Log
+func TestTBLogsThroughTest(t *testing.T) {
+	tb := &recordingTB{}
+	l := NewTB(tb, core.INFO)
+	defer l.Close()
+
+	l.Debug("hidden")
+	l.InfoFields("visible", core.Int("n", 1))
+	l.Error("error entry")
+
+	if len(tb.lines) != 2 || tb.lines[0] != "[INFO] visible {n=1}" {
+		t.Errorf("Unexpected lines %q", tb.lines)
+	}
+	if tb.failed {
+		t.Error("Expected the test not to fail without FailOnError")
+	}
+}
+
+// TestTBFailOnError tests failing the test for ERROR and higher entries
+func TestTBFailOnError(t *testing.T) {
+	tb := &recordingTB{}
+	l := NewTBWithConfig(tb, TBConfig{LoggerConfig: logger.LoggerConfig{Level: core.DEBUG}, FailOnError: true})
+	defer l.Close()
+
+	l.Warn("warning")
+	if tb.failed {
+		t.Fatal("Expected a warning not to fail the test")
+	}
+	l.Error("error entry")
+	if !tb.failed {
+		t.Error("Expected an error entry to fail the test")
+	}
+}
+
+// TestTBFailOnErrorAfterFatal tests that a FATAL entry does not stop later entries from failing the test
+func TestTBFailOnErrorAfterFatal(t *testing.T) {
+	tb := &recordingTB{}
+	l := NewTBWithConfig(tb, TBConfig{LoggerConfig: logger.LoggerConfig{Level: core.DEBUG}, FailOnError: true})
+	defer l.Close()
+
+	l.Fatal("fatal entry")
+	if !tb.failed {
+		t.Fatal("Expected a fatal entry to fail the test")
+	}
+	tb.failed = false
+	l.Error("error entry")
+	if !tb.failed {
+		t.Error("Expected an error entry after FATAL to fail the test")
+	}
+}
+
+// TestTBAfterTestFinished tests that entries logged after the test are dropped with a warning
+func TestTBAfterTestFinished(t *testing.T) {
+	var mu sync.Mutex
+	var warnings []string
+	handler := func(err error) {
+		mu.Lock()
+		warnings = append(warnings, err.Error())
+		mu.Unlock()
+	}
+
+	var l *logger.Logger
+	t.Run("sub", func(t *testing.T) {
+		l = NewTBWithConfig(t, TBConfig{
+			LoggerConfig: logger.LoggerConfig{Level: core.INFO, ErrorHandler: handler},
+			FailOnError:  true,
+		})
+		l.Info("during the test")
+	})
+	defer l.Close()
+
+	l.Error("after the test")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "dropped entry logged after TestTBAfterTestFinished/sub finished") ||
+		!strings.Contains(warnings[0], "after the test") {
+		t.Errorf("Unexpected warnings %q", warnings)
+	}
+}
+
+// TestTBConcurrentFinish tests logging from a goroutine while the test finishes
+func TestTBConcurrentFinish(t *testing.T) {
+	tb := &recordingTB{}
+	l := NewTBWithConfig(tb, TBConfig{LoggerConfig: logger.LoggerConfig{Level: core.INFO, ErrorHandler: func(error) {}}})
+	defer l.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			l.Info("entry")
+		}
+	}()
+	tb.finish()
+	wg.Wait()
+
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	if len(tb.lines) > 100 {
+		t.Errorf("Expected at most 100 lines, got %d", len(tb.lines))
+	}
+}
@@ -0,0 +1,172 @@
+// Package mire provides a process-wide default logger for code that does not pass a
+// *logger.Logger around, such as small tools and tests. The functions of this package
+// log through the logger returned by L, which can be swapped with ReplaceGlobals.
+//
+// The default logger writes text entries of level INFO and above to stdout without
+// buffering, so nothing is lost when the process exits without closing it.
+package mire
+
+import (
+	"os"
+	"sync/atomic"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/logger"
+)
+
+// globals is the current global logger and its copy used by the package functions
+type globals struct {
+	logger  *logger.Logger
+	wrapped *logger.Logger // Skips the frame of the package function for caller info and level rules
+}
+
+// current holds the global logger, created on first use
+var current atomic.Pointer[globals]
+
+// newGlobals wraps l for the package functions
+func newGlobals(l *logger.Logger) *globals {
+	return &globals{logger: l, wrapped: l.AddCallerSkip(1)}
+}
+
+// newDefaultLogger creates the logger used until ReplaceGlobals is called
+func newDefaultLogger() *logger.Logger {
+	return logger.New(logger.LoggerConfig{
+		Level:  core.INFO,
+		Output: os.Stdout,
+		Formatter: &formatter.TextFormatter{
+			ShowTimestamp:   true,
+			TimestampFormat: logger.DEFAULT_TIMESTAMP_FORMAT,
+		},
+	})
+}
+
+// load returns the current globals, creating the default logger on first use
+func load() *globals {
+	if g := current.Load(); g != nil {
+		return g
+	}
+	g := newGlobals(newDefaultLogger())
+	if current.CompareAndSwap(nil, g) {
+		return g
+	}
+	g.logger.Close() // Lost the race against another first use
+	return current.Load()
+}
+
+// L returns the global logger. The package functions log through a copy of it made
+// with AddCallerSkip, which shares its settings and the hooks added with AddHook.
+func L() *logger.Logger {
+	return load().logger
+}
+
+// ReplaceGlobals makes l the global logger and returns a function that restores the
+// previous one. Passing nil restores the built-in default logger. Loggers derived
+// from the previous global with With or Named keep logging through it.
+func ReplaceGlobals(l *logger.Logger) func() {
+	var g *globals
+	if l != nil {
+		g = newGlobals(l)
+	}
+	prev := current.Swap(g)
+	return func() {
+		current.Store(prev)
+	}
+}
+
+// With returns a logger derived from the global logger with additional typed fields
+func With(fields ...core.Field) *logger.Logger { return L().With(fields...) }
+
+// Named returns a logger derived from the global logger with the name appended, see Logger.Named
+func Named(name string) *logger.Logger { return L().Named(name) }
+
+// Sync flushes the global logger, see Logger.Sync
+func Sync() error { return L().Sync() }
+
+// Trace logs a message at TRACE level through the global logger
+func Trace(args ...interface{}) { load().wrapped.Trace(args...) }
+
+// Debug logs a message at DEBUG level through the global logger
+func Debug(args ...interface{}) { load().wrapped.Debug(args...) }
+
+// Info logs a message at INFO level through the global logger
+func Info(args ...interface{}) { load().wrapped.Info(args...) }
+
+// Notice logs a message at NOTICE level through the global logger
+func Notice(args ...interface{}) { load().wrapped.Notice(args...) }
+
+// Warn logs a message at WARN level through the global logger
+func Warn(args ...interface{}) { load().wrapped.Warn(args...) }
+
+// Error logs a message at ERROR level through the global logger
+func Error(args ...interface{}) { load().wrapped.Error(args...) }
+
+// Fatal logs a message at FATAL level through the global logger and exits
+func Fatal(args ...interface{}) { load().wrapped.Fatal(args...) }
+
+// Panic logs a message at PANIC level through the global logger
+func Panic(args ...interface{}) { load().wrapped.Panic(args...) }
+
+// Tracef logs a formatted message at TRACE level through the global logger
+func Tracef(format string, args ...interface{}) { load().wrapped.Tracef(format, args...) }
+
+// Debugf logs a formatted message at DEBUG level through the global logger
+func Debugf(format string, args ...interface{}) { load().wrapped.Debugf(format, args...) }
+
+// Infof logs a formatted message at INFO level through the global logger
+func Infof(format string, args ...interface{}) { load().wrapped.Infof(format, args...) }
+
+// Noticef logs a formatted message at NOTICE level through the global logger
+func Noticef(format string, args ...interface{}) { load().wrapped.Noticef(format, args...) }
+
+// Warnf logs a formatted message at WARN level through the global logger
+func Warnf(format string, args ...interface{}) { load().wrapped.Warnf(format, args...) }
+
+// Errorf logs a formatted message at ERROR level through the global logger
+func Errorf(format string, args ...interface{}) { load().wrapped.Errorf(format, args...) }
+
+// Fatalf logs a formatted message at FATAL level through the global logger and exits
+func Fatalf(format string, args ...interface{}) { load().wrapped.Fatalf(format, args...) }
+
+// Panicf logs a formatted message at PANIC level through the global logger
+func Panicf(format string, args ...interface{}) { load().wrapped.Panicf(format, args...) }
+
+// TraceFields logs a message with typed fields at TRACE level through the global logger
+func TraceFields(message string, fields ...core.Field) {
+	load().wrapped.TraceFields(message, fields...)
+}
+
+// DebugFields logs a message with typed fields at DEBUG level through the global logger
+func DebugFields(message string, fields ...core.Field) {
+	load().wrapped.DebugFields(message, fields...)
+}
+
+// InfoFields logs a message with typed fields at INFO level through the global logger
+func InfoFields(message string, fields ...core.Field) {
+	load().wrapped.InfoFields(message, fields...)
+}
+
+// NoticeFields logs a message with typed fields at NOTICE level through the global logger
+func NoticeFields(message string, fields ...core.Field) {
+	load().wrapped.NoticeFields(message, fields...)
+}
+
+// WarnFields logs a message with typed fields at WARN level through the global logger
+func WarnFields(message string, fields ...core.Field) {
+	load().wrapped.WarnFields(message, fields...)
+}
+
+// ErrorFields logs a message with typed fields at ERROR level through the global logger
+func ErrorFields(message string, fields ...core.Field) {
+	load().wrapped.ErrorFields(message, fields...)
+}
+
+// FatalFields logs a message with typed fields at FATAL level through the global logger and exits
+func FatalFields(message string, fields ...core.Field) {
+	load().wrapped.FatalFields(message, fields...)
+}
+
+// PanicFields logs a message with typed fields at PANIC level through the global logger
+func PanicFields(message string, fields ...core.Field) {
+	load().wrapped.PanicFields(message, fields...)
+}
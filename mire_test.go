@@ -0,0 +1,144 @@
+package mire
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Lunar-Chipter/mire/core"
+	"github.com/Lunar-Chipter/mire/formatter"
+	"github.com/Lunar-Chipter/mire/logger"
+)
+
+// newTestLogger creates a logger writing text without timestamps to buf
+func newTestLogger(buf *bytes.Buffer) *logger.Logger {
+	return logger.New(logger.LoggerConfig{
+		Level:     core.DEBUG,
+		Output:    buf,
+		Formatter: &formatter.TextFormatter{},
+	})
+}
+
+// TestDefaultGlobal tests the built-in default logger
+func TestDefaultGlobal(t *testing.T) {
+	defer ReplaceGlobals(nil)()
+
+	l := L()
+	if l == nil || L() != l {
+		t.Fatal("Expected a single default logger")
+	}
+	if l.GetLevel() != core.INFO {
+		t.Errorf("Expected the default level INFO, got %v", l.GetLevel())
+	}
+}
+
+// TestReplaceGlobals tests swapping and restoring the global logger
+func TestReplaceGlobals(t *testing.T) {
+	var first, second bytes.Buffer
+	restoreFirst := ReplaceGlobals(newTestLogger(&first))
+	defer restoreFirst()
+
+	Info("one")
+	Debugf("two %d", 2)
+	WarnFields("three", core.Int("n", 3))
+
+	restoreSecond := ReplaceGlobals(newTestLogger(&second))
+	Error("four")
+	restoreSecond()
+	Info("five")
+
+	got := first.String()
+	for _, want := range []string{"[INFO] one", "[DEBUG] two 2", "[WARN] three {n=3}", "[INFO] five"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in the first logger output %q", want, got)
+		}
+	}
+	if strings.Contains(got, "four") || !strings.Contains(second.String(), "[ERROR] four") {
+		t.Errorf("Expected four only in the second logger, got %q and %q", got, second.String())
+	}
+}
+
+// TestGlobalDerivedLoggers tests With and Named on the global logger
+func TestGlobalDerivedLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	defer ReplaceGlobals(newTestLogger(&buf))()
+
+	With(core.String("user", "ana")).Info("with")
+	Named("db").Info("named")
+
+	if got := buf.String(); !strings.Contains(got, "with {user=ana}") || !strings.Contains(got, "logger=db named") {
+		t.Errorf("Unexpected derived logger output %q", got)
+	}
+}
+
+// countingHook counts the entries it is fired with
+type countingHook struct {
+	mu    sync.Mutex
+	fired int
+}
+
+func (h *countingHook) Fire(*core.LogEntry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fired++
+	return nil
+}
+
+func (h *countingHook) Close() error { return nil }
+
+// TestGlobalAddHook tests that hooks added to L after ReplaceGlobals see the package functions
+func TestGlobalAddHook(t *testing.T) {
+	var buf bytes.Buffer
+	defer ReplaceGlobals(newTestLogger(&buf))()
+
+	h := &countingHook{}
+	L().AddHook(h)
+	Info("one")
+	ErrorFields("two")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.fired != 2 {
+		t.Errorf("Expected the hook to be fired twice, got %d", h.fired)
+	}
+}
+
+// TestGlobalCallerLevelRules tests that level rules see the caller of the package functions
+func TestGlobalCallerLevelRules(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf)
+	if err := l.SetLevelRules(logger.LevelRule{Function: "TestGlobalCallerLevelRules", Level: core.ERROR}); err != nil {
+		t.Fatal(err)
+	}
+	defer ReplaceGlobals(l)()
+
+	Info("filtered by the rule for this test")
+	Error("kept")
+
+	if got := buf.String(); strings.Contains(got, "filtered") || !strings.Contains(got, "kept") {
+		t.Errorf("Expected the rule for the test function to apply, got %q", got)
+	}
+}
+
+// TestGlobalConcurrentReplace tests replacing the global logger while logging
+func TestGlobalConcurrentReplace(t *testing.T) {
+	defer ReplaceGlobals(nil)()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				Debug("entry")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			var buf bytes.Buffer
+			ReplaceGlobals(logger.New(logger.LoggerConfig{Level: core.ERROR, Output: &buf}))
+		}()
+	}
+	wg.Wait()
+}
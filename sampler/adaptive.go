@@ -0,0 +1,189 @@
+package sampler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// DEFAULT_ADAPTIVE_WINDOW is the interval at which an AdaptiveSampler adjusts its ratio by default
+const DEFAULT_ADAPTIVE_WINDOW = time.Second
+
+// DEFAULT_ADAPTIVE_SMOOTHING is the weight of the latest window in the observed volume by default
+const DEFAULT_ADAPTIVE_SMOOTHING = 0.3
+
+// entryOverhead approximates the bytes a formatter adds to every entry for the
+// timestamp, level and separators
+const entryOverhead = 48
+
+// AdaptiveBudget is the volume a level may write per second
+type AdaptiveBudget struct {
+	EntriesPerSecond float64 // 0 or less means no entry budget
+	BytesPerSecond   float64 // Estimated from the message and fields; 0 or less means no byte budget
+}
+
+// AdaptiveSamplerConfig configures an AdaptiveSampler
+type AdaptiveSamplerConfig struct {
+	Default   AdaptiveBudget                // Budget of the levels without an entry in Levels
+	Levels    map[core.Level]AdaptiveBudget // Budgets per level
+	Window    time.Duration                 // Interval at which the ratio is adjusted, defaults to DEFAULT_ADAPTIVE_WINDOW
+	Smoothing float64                       // Weight of the latest window in the observed volume, from 0 to 1; defaults to DEFAULT_ADAPTIVE_SMOOTHING
+}
+
+// AdaptiveSampler keeps each level within a budget of entries or bytes per second.
+// At the end of every window it compares the smoothed volume logged at the level
+// with the budget and sets the fraction of entries it keeps accordingly, so quiet
+// periods keep everything and bursts are thinned out. Within a window the budget is
+// also a hard cap, so a sudden burst is limited before the ratio catches up.
+type AdaptiveSampler struct {
+	window    time.Duration
+	smoothing float64
+	levels    [numLevels]adaptiveLevel
+}
+
+// adaptiveLevel is the state of one level
+type adaptiveLevel struct {
+	budget AdaptiveBudget
+
+	mu          sync.Mutex
+	windowStart time.Time
+	observed    bool    // A window has ended and the rates are set
+	seenEntries float64 // Entries logged in the current window
+	seenBytes   float64
+	keptEntries float64 // Entries kept in the current window
+	keptBytes   float64
+	rateEntries float64 // Smoothed entries logged per second
+	rateBytes   float64
+	ratio       float64 // Fraction of entries kept
+	credit      float64 // Accumulates ratio, an entry is kept for every whole unit
+}
+
+// NewAdaptiveSampler creates an AdaptiveSampler
+func NewAdaptiveSampler(config AdaptiveSamplerConfig) *AdaptiveSampler {
+	s := &AdaptiveSampler{window: config.Window, smoothing: config.Smoothing}
+	if s.window <= 0 {
+		s.window = DEFAULT_ADAPTIVE_WINDOW
+	}
+	if s.smoothing <= 0 || s.smoothing > 1 {
+		s.smoothing = DEFAULT_ADAPTIVE_SMOOTHING
+	}
+	for i := range s.levels {
+		budget, ok := config.Levels[core.Level(i)]
+		if !ok {
+			budget = config.Default
+		}
+		s.levels[i].budget = budget
+		s.levels[i].ratio = 1
+	}
+	return s
+}
+
+// limited reports whether the budget limits anything
+func (b AdaptiveBudget) limited() bool {
+	return b.EntriesPerSecond > 0 || b.BytesPerSecond > 0
+}
+
+// Sample implements Sampler. Windows are measured with the entry timestamps.
+func (s *AdaptiveSampler) Sample(entry *core.LogEntry) bool {
+	level := entry.Level
+	if level < core.TRACE || level > core.PANIC {
+		return true
+	}
+	lv := &s.levels[level]
+	if !lv.budget.limited() {
+		return true
+	}
+	size := float64(estimateSize(entry))
+
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	now := entry.Timestamp
+	if lv.windowStart.IsZero() {
+		lv.windowStart = now
+	} else if elapsed := now.Sub(lv.windowStart); elapsed >= s.window {
+		lv.adjust(elapsed.Seconds(), s.smoothing)
+		lv.windowStart = now
+	}
+	lv.seenEntries++
+	lv.seenBytes += size
+
+	lv.credit += lv.ratio
+	if lv.credit < 1 {
+		return false
+	}
+	lv.credit--
+
+	// The budget of the window caps a burst before the ratio adapts. The first entry of
+	// a window is always kept, so budgets below one entry per window are met by the ratio.
+	windowSeconds := s.window.Seconds()
+	if b := lv.budget.EntriesPerSecond; b > 0 && lv.keptEntries > 0 && lv.keptEntries+1 > b*windowSeconds {
+		return false
+	}
+	if b := lv.budget.BytesPerSecond; b > 0 && lv.keptBytes > 0 && lv.keptBytes+size > b*windowSeconds {
+		return false
+	}
+	lv.keptEntries++
+	lv.keptBytes += size
+	return true
+}
+
+// adjust folds the window that lasted seconds into the smoothed rates, sets the
+// ratio for the next window and starts it. lv.mu must be held.
+func (lv *adaptiveLevel) adjust(seconds, smoothing float64) {
+	entries, bytes := lv.seenEntries/seconds, lv.seenBytes/seconds
+	if lv.observed {
+		entries = smoothing*entries + (1-smoothing)*lv.rateEntries
+		bytes = smoothing*bytes + (1-smoothing)*lv.rateBytes
+	}
+	lv.rateEntries, lv.rateBytes, lv.observed = entries, bytes, true
+
+	ratio := 1.0
+	if b := lv.budget.EntriesPerSecond; b > 0 && entries > b {
+		ratio = b / entries
+	}
+	if b := lv.budget.BytesPerSecond; b > 0 && bytes > 0 && b/bytes < ratio {
+		ratio = b / bytes
+	}
+	lv.ratio = ratio
+	lv.seenEntries, lv.seenBytes, lv.keptEntries, lv.keptBytes = 0, 0, 0, 0
+}
+
+// Rate returns the fraction of entries currently kept at level
+func (s *AdaptiveSampler) Rate(level core.Level) float64 {
+	if level < core.TRACE || level > core.PANIC {
+		return 1
+	}
+	lv := &s.levels[level]
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	return lv.ratio
+}
+
+// Rates implements RateReporter for the levels with a budget
+func (s *AdaptiveSampler) Rates() map[core.Level]float64 {
+	rates := make(map[core.Level]float64)
+	for i := range s.levels {
+		if s.levels[i].budget.limited() {
+			rates[core.Level(i)] = s.Rate(core.Level(i))
+		}
+	}
+	return rates
+}
+
+// estimateSize approximates the formatted size of entry from its message, name and fields
+func estimateSize(entry *core.LogEntry) int {
+	n := entryOverhead + len(entry.Message) + len(entry.LoggerName)
+	for k, v := range entry.Fields {
+		n += len(k) + len(v) + 2
+	}
+	for i := range entry.TypedFields {
+		f := &entry.TypedFields[i]
+		n += len(f.Key) + len(f.String) + len(f.Bytes) + 2
+		if f.Type != core.StringType && f.Type != core.BytesType {
+			n += 8
+		}
+	}
+	return n
+}
@@ -0,0 +1,156 @@
+package sampler
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// feed logs perSecond entries per second for the given seconds, spread evenly, and
+// returns the number kept in the last second
+func feed(s Sampler, level core.Level, start time.Time, seconds, perSecond int) (time.Time, int) {
+	step := time.Second / time.Duration(perSecond)
+	now := start
+	kept := 0
+	for sec := 0; sec < seconds; sec++ {
+		kept = 0
+		for i := 0; i < perSecond; i++ {
+			if s.Sample(tickEntry(level, "request", now)) {
+				kept++
+			}
+			now = now.Add(step)
+		}
+	}
+	return now, kept
+}
+
+// TestAdaptiveSamplerQuiet tests that volume within the budget is kept
+func TestAdaptiveSamplerQuiet(t *testing.T) {
+	s := NewAdaptiveSampler(AdaptiveSamplerConfig{Default: AdaptiveBudget{EntriesPerSecond: 100}})
+	if _, kept := feed(s, core.INFO, time.Now(), 5, 50); kept != 50 {
+		t.Errorf("Expected all 50 entries to be kept, got %d", kept)
+	}
+	if rate := s.Rate(core.INFO); rate != 1 {
+		t.Errorf("Expected rate 1, got %v", rate)
+	}
+}
+
+// TestAdaptiveSamplerBurst tests converging to the budget and recovering afterwards
+func TestAdaptiveSamplerBurst(t *testing.T) {
+	s := NewAdaptiveSampler(AdaptiveSamplerConfig{Default: AdaptiveBudget{EntriesPerSecond: 100}, Smoothing: 0.5})
+	now := time.Now()
+
+	// The first second is capped by the budget before the rate is known
+	now, kept := feed(s, core.INFO, now, 1, 1000)
+	if kept != 100 {
+		t.Errorf("Expected the first window to be capped at 100, got %d", kept)
+	}
+	now, kept = feed(s, core.INFO, now, 10, 1000)
+	if kept < 90 || kept > 100 {
+		t.Errorf("Expected about 100 entries per second, got %d", kept)
+	}
+	if rate := s.Rate(core.INFO); math.Abs(rate-0.1) > 0.01 {
+		t.Errorf("Expected rate 0.1, got %v", rate)
+	}
+
+	// The rate rises again once the burst is over
+	if _, kept = feed(s, core.INFO, now, 10, 20); kept != 20 {
+		t.Errorf("Expected all entries after the burst, got %d", kept)
+	}
+	if rate := s.Rate(core.INFO); rate != 1 {
+		t.Errorf("Expected rate 1 after the burst, got %v", rate)
+	}
+}
+
+// TestAdaptiveSamplerBytes tests the bytes per second budget
+func TestAdaptiveSamplerBytes(t *testing.T) {
+	s := NewAdaptiveSampler(AdaptiveSamplerConfig{Default: AdaptiveBudget{BytesPerSecond: 10000}})
+	size := estimateSize(tickEntry(core.INFO, "request", time.Time{}))
+	_, kept := feed(s, core.INFO, time.Now(), 10, 1000)
+	if want := 10000 / size; kept < want*9/10 || kept > want {
+		t.Errorf("Expected about %d entries of %d bytes per second, got %d", want, size, kept)
+	}
+}
+
+// TestAdaptiveSamplerLevels tests per level budgets
+func TestAdaptiveSamplerLevels(t *testing.T) {
+	s := NewAdaptiveSampler(AdaptiveSamplerConfig{
+		Levels: map[core.Level]AdaptiveBudget{core.DEBUG: {EntriesPerSecond: 10}},
+	})
+	now := time.Now()
+	if _, kept := feed(s, core.INFO, now, 3, 1000); kept != 1000 {
+		t.Errorf("Expected levels without a budget to keep everything, got %d", kept)
+	}
+	if _, kept := feed(s, core.DEBUG, now, 3, 1000); kept > 10 {
+		t.Errorf("Expected at most 10 DEBUG entries per second, got %d", kept)
+	}
+
+	rates := s.Rates()
+	if len(rates) != 1 || rates[core.DEBUG] >= 1 {
+		t.Errorf("Expected only the DEBUG rate below 1, got %v", rates)
+	}
+}
+
+// TestAdaptiveSamplerConcurrent tests sampling from several goroutines
+func TestAdaptiveSamplerConcurrent(t *testing.T) {
+	s := NewAdaptiveSampler(AdaptiveSamplerConfig{Default: AdaptiveBudget{EntriesPerSecond: 100}})
+	now := time.Now()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	kept := 0
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := countKept(s, 500, core.INFO, "request", now)
+			mu.Lock()
+			kept += n
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if kept != 100 {
+		t.Errorf("Expected the window to be capped at 100, got %d", kept)
+	}
+}
+
+// TestChainRates tests combining the rates of chained samplers
+func TestChainRates(t *testing.T) {
+	a := NewAdaptiveSampler(AdaptiveSamplerConfig{Default: AdaptiveBudget{EntriesPerSecond: 100}})
+	b := NewAdaptiveSampler(AdaptiveSamplerConfig{Levels: map[core.Level]AdaptiveBudget{core.INFO: {EntriesPerSecond: 10}}})
+	feed(b, core.INFO, time.Now(), 3, 100)
+
+	c := Chain(a, NewHashSampler(HashSamplerConfig{Probability: 0.5}), b).(RateReporter)
+	rates := c.Rates()
+	if got, want := rates[core.INFO], b.Rate(core.INFO); got != want || got >= 1 {
+		t.Errorf("Expected the INFO rate %v, got %v", want, got)
+	}
+	if rates[core.WARN] != 1 {
+		t.Errorf("Expected the WARN rate 1, got %v", rates[core.WARN])
+	}
+	if rates := Chain(NewHashSampler(HashSamplerConfig{Probability: 0.5})).(RateReporter).Rates(); rates != nil {
+		t.Errorf("Expected no rates without a reporter, got %v", rates)
+	}
+}
+
+// TestAdaptiveSamplerBudgetBelowWindow tests a budget of less than one entry per window
+func TestAdaptiveSamplerBudgetBelowWindow(t *testing.T) {
+	s := NewAdaptiveSampler(AdaptiveSamplerConfig{Default: AdaptiveBudget{EntriesPerSecond: 0.5}})
+	now := time.Now()
+	kept := 0
+	for i := 0; i < 600; i++ {
+		if s.Sample(tickEntry(core.INFO, "request", now)) {
+			kept++
+		}
+		now = now.Add(time.Second)
+	}
+	if kept < 290 || kept > 310 {
+		t.Errorf("Expected about 300 of 600 entries, got %d", kept)
+	}
+	if rate := s.Rate(core.INFO); math.Abs(rate-0.5) > 0.01 {
+		t.Errorf("Expected rate 0.5, got %v", rate)
+	}
+}
@@ -0,0 +1,79 @@
+package sampler
+
+import "github.com/Lunar-Chipter/mire/core"
+
+// chain keeps the entries that every sampler keeps
+type chain []Sampler
+
+// Chain returns a Sampler that keeps an entry only if every sampler keeps it.
+// Samplers run in order and the ones after a dropping sampler do not see the
+// entry, so e.g. a RateLimiter placed last only counts what the others kept.
+func Chain(samplers ...Sampler) Sampler {
+	c := make(chain, 0, len(samplers))
+	for _, s := range samplers {
+		if s != nil {
+			c = append(c, s)
+		}
+	}
+	return c
+}
+
+// Sample implements Sampler
+func (c chain) Sample(entry *core.LogEntry) bool {
+	// Entries that are always written only reach the samplers that hold entries back
+	if entry.Level >= core.FATAL {
+		for _, s := range c {
+			if _, ok := s.(Releaser); ok {
+				s.Sample(entry)
+			}
+		}
+		return true
+	}
+	for _, s := range c {
+		if !s.Sample(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetRelease implements Releaser by passing release to the samplers that hold entries back
+func (c chain) SetRelease(release func(entry *core.LogEntry)) {
+	for _, s := range c {
+		if r, ok := s.(Releaser); ok {
+			r.SetRelease(release)
+		}
+	}
+}
+
+// Rates implements RateReporter. The rate of a level is the product of the rates
+// reported by the samplers for it; nil if no sampler reports rates.
+func (c chain) Rates() map[core.Level]float64 {
+	var rates map[core.Level]float64
+	for _, s := range c {
+		r, ok := s.(RateReporter)
+		if !ok {
+			continue
+		}
+		if rates == nil {
+			rates = make(map[core.Level]float64)
+		}
+		for level, rate := range r.Rates() {
+			if prev, ok := rates[level]; ok {
+				rate *= prev
+			}
+			rates[level] = rate
+		}
+	}
+	return rates
+}
+
+// UsesCaller implements CallerSampler, reporting whether any sampler uses the call site
+func (c chain) UsesCaller() bool {
+	for _, s := range c {
+		if cs, ok := s.(CallerSampler); ok && cs.UsesCaller() {
+			return true
+		}
+	}
+	return false
+}
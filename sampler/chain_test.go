@@ -0,0 +1,73 @@
+package sampler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// countingSampler keeps entries while keep is true and counts the entries it sees
+type countingSampler struct {
+	keep bool
+	seen int
+}
+
+func (s *countingSampler) Sample(entry *core.LogEntry) bool {
+	s.seen++
+	return s.keep
+}
+
+// TestChain tests combining samplers
+func TestChain(t *testing.T) {
+	first, second := &countingSampler{keep: true}, &countingSampler{keep: true}
+	c := Chain(first, nil, second)
+	entry := &core.LogEntry{Level: core.INFO, Timestamp: time.Now()}
+
+	if !c.Sample(entry) || first.seen != 1 || second.seen != 1 {
+		t.Errorf("Expected both samplers to keep the entry, seen %d and %d", first.seen, second.seen)
+	}
+	first.keep = false
+	if c.Sample(entry) || second.seen != 1 {
+		t.Errorf("Expected the chain to stop at the first dropping sampler, second saw %d", second.seen)
+	}
+	if !Chain().Sample(entry) {
+		t.Error("Expected an empty chain to keep every entry")
+	}
+}
+
+// TestChainSetRelease tests that a chain passes the release function on
+func TestChainSetRelease(t *testing.T) {
+	s := NewTailSampler(TailSamplerConfig{})
+	rec := &releaseRecorder{}
+	Chain(&countingSampler{keep: true}, s).(Releaser).SetRelease(rec.release)
+
+	now := time.Now()
+	s.Sample(traceEntry(core.DEBUG, "a", "held", now))
+	s.Sample(traceEntry(core.ERROR, "a", "failed", now))
+	if len(rec.messages) != 1 {
+		t.Errorf("Expected the chain to set the release function, got %q", rec.messages)
+	}
+}
+
+// TestChainFatal tests that FATAL entries are kept and only reach samplers that hold entries back
+func TestChainFatal(t *testing.T) {
+	counting := &countingSampler{keep: true}
+	tail := NewTailSampler(TailSamplerConfig{})
+	rec := &releaseRecorder{}
+	c := Chain(counting, tail)
+	c.(Releaser).SetRelease(rec.release)
+
+	now := time.Now()
+	c.Sample(traceEntry(core.DEBUG, "a", "held", now))
+	counting.keep = false
+	if !c.Sample(traceEntry(core.FATAL, "a", "fatal", now)) {
+		t.Error("Expected the chain to keep a FATAL entry")
+	}
+	if counting.seen != 1 {
+		t.Errorf("Expected the counting sampler to see only the DEBUG entry, saw %d", counting.seen)
+	}
+	if len(rec.messages) != 1 || rec.messages[0] != "held" {
+		t.Errorf("Expected the tail sampler to release the held entry, got %q", rec.messages)
+	}
+}
@@ -0,0 +1,95 @@
+package sampler
+
+import (
+	"math"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// HashSamplerConfig configures a HashSampler
+type HashSamplerConfig struct {
+	Probability float64      // Fraction of keys kept, from 0 to 1
+	Field       string       // Field whose value is hashed instead of LogEntry.TraceID, e.g. "request_id"
+	Exempt      []core.Level // Levels that are always kept, e.g. ERROR
+}
+
+// HashSampler keeps or drops entries by a hash of their trace ID, or of another
+// field, so all entries of a trace share one decision. The decision only depends on
+// the key and the probability: services sampling at the same probability keep the
+// same traces, and their logs line up. Entries without a key are kept.
+//
+// The key is hashed with 64-bit FNV-1a followed by the MurmurHash3 fmix64 finalizer
+// and kept if the hash is below Probability × 2^64.
+type HashSampler struct {
+	field     string
+	exempt    uint32 // Bit set of exempt levels
+	all       bool   // Probability of 1 or more
+	threshold uint64
+}
+
+// NewHashSampler creates a HashSampler
+func NewHashSampler(config HashSamplerConfig) *HashSampler {
+	s := &HashSampler{field: config.Field}
+	switch p := config.Probability; {
+	case p >= 1:
+		s.all = true
+	case p > 0:
+		s.threshold = uint64(p * math.Exp2(64))
+	}
+	for _, level := range config.Exempt {
+		if level >= core.TRACE && level <= core.PANIC {
+			s.exempt |= 1 << uint(level)
+		}
+	}
+	return s
+}
+
+// Sample implements Sampler
+func (s *HashSampler) Sample(entry *core.LogEntry) bool {
+	if s.all {
+		return true
+	}
+	level := entry.Level
+	if level >= core.TRACE && level <= core.PANIC && s.exempt&(1<<uint(level)) != 0 {
+		return true
+	}
+
+	if s.field == "" {
+		if len(entry.TraceID) == 0 {
+			return true
+		}
+		return s.KeepsBytes(entry.TraceID)
+	}
+	key := fieldString(entry, s.field)
+	if key == "" {
+		return true
+	}
+	return s.Keeps(key)
+}
+
+// Keeps reports whether entries with the key are kept, e.g. to decide whether to
+// propagate a sampled flag with a request
+func (s *HashSampler) Keeps(key string) bool {
+	return s.all || hashKey64(key) < s.threshold
+}
+
+// KeepsBytes is Keeps for a key held in a byte slice
+func (s *HashSampler) KeepsBytes(key []byte) bool {
+	return s.all || hashKey64(core.BytesToString(key)) < s.threshold
+}
+
+// hashKey64 returns the 64-bit FNV-1a hash of key mixed with the fmix64 finalizer,
+// which spreads keys that differ only in their last bytes over the whole range
+func hashKey64(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
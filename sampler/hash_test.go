@@ -0,0 +1,92 @@
+package sampler
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// TestHashSamplerDeterministic tests that samplers agree on every trace
+func TestHashSamplerDeterministic(t *testing.T) {
+	a := NewHashSampler(HashSamplerConfig{Probability: 0.1})
+	b := NewHashSampler(HashSamplerConfig{Probability: 0.1})
+	now := time.Now()
+
+	kept := 0
+	for i := 0; i < 10000; i++ {
+		trace := "trace-" + strconv.Itoa(i)
+		keepA := a.Sample(traceEntry(core.INFO, trace, "a", now))
+		if keepB := b.Sample(traceEntry(core.DEBUG, trace, "b", now)); keepA != keepB {
+			t.Fatalf("Samplers disagree on %s", trace)
+		}
+		if keepA {
+			kept++
+		}
+	}
+	if kept < 900 || kept > 1100 {
+		t.Errorf("Expected about 10%% of 10000 traces, got %d", kept)
+	}
+}
+
+// TestHashSamplerNested tests that a higher probability keeps a superset of the traces
+func TestHashSamplerNested(t *testing.T) {
+	low := NewHashSampler(HashSamplerConfig{Probability: 0.1})
+	high := NewHashSampler(HashSamplerConfig{Probability: 0.5})
+	for i := 0; i < 1000; i++ {
+		key := strconv.Itoa(i)
+		if low.Keeps(key) && !high.Keeps(key) {
+			t.Fatalf("Expected %s kept at 10%% to be kept at 50%%", key)
+		}
+	}
+}
+
+// TestHashSamplerBounds tests the probabilities 0 and 1 and entries without a key
+func TestHashSamplerBounds(t *testing.T) {
+	now := time.Now()
+	none := NewHashSampler(HashSamplerConfig{Probability: 0})
+	all := NewHashSampler(HashSamplerConfig{Probability: 1})
+	for i := 0; i < 100; i++ {
+		trace := strconv.Itoa(i)
+		if none.Sample(traceEntry(core.INFO, trace, "", now)) {
+			t.Fatal("Expected probability 0 to drop every trace")
+		}
+		if !all.Sample(traceEntry(core.INFO, trace, "", now)) {
+			t.Fatal("Expected probability 1 to keep every trace")
+		}
+	}
+	if !none.Sample(traceEntry(core.INFO, "", "no trace", now)) {
+		t.Error("Expected entries without trace ID to be kept")
+	}
+}
+
+// TestHashSamplerFieldAndExempt tests hashing a field and exempt levels
+func TestHashSamplerFieldAndExempt(t *testing.T) {
+	s := NewHashSampler(HashSamplerConfig{Field: "request_id", Exempt: []core.Level{core.ERROR}})
+	entry := &core.LogEntry{Level: core.INFO, TraceID: []byte("ignored"), TypedFields: []core.Field{core.String("request_id", "r1")}}
+
+	if s.Sample(entry) {
+		t.Error("Expected the field value to be sampled at probability 0")
+	}
+	entry.Level = core.ERROR
+	if !s.Sample(entry) {
+		t.Error("Expected ERROR to be exempt")
+	}
+	if !s.Sample(&core.LogEntry{Level: core.INFO, TraceID: []byte("t")}) {
+		t.Error("Expected entries without the field to be kept")
+	}
+	if s.KeepsBytes([]byte("r1")) != s.Keeps("r1") {
+		t.Error("Expected Keeps and KeepsBytes to agree")
+	}
+}
+
+// BenchmarkHashSampler measures sampling by trace ID
+func BenchmarkHashSampler(b *testing.B) {
+	s := NewHashSampler(HashSamplerConfig{Probability: 0.1})
+	entry := traceEntry(core.INFO, "4bf92f3577b34da6a3ce929d0e0e4736", "msg", time.Now())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Sample(entry)
+	}
+}
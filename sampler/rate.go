@@ -0,0 +1,227 @@
+package sampler
+
+import (
+	"bytes"
+	"container/list"
+	"math"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// DEFAULT_RATE_LIMIT_KEYS is the number of buckets a RateLimiter keeps by default
+const DEFAULT_RATE_LIMIT_KEYS = 10000
+
+// SUPPRESSED_KEY is the field that reports how many entries of a bucket were dropped
+// before the entry that carries it
+const SUPPRESSED_KEY = "suppressed"
+
+// RateLimit is a token bucket limit
+type RateLimit struct {
+	Rate  float64 // Entries per second; 0 or less means unlimited
+	Burst int     // Entries that may be written at once, defaults to the rate rounded up
+}
+
+// RateLimiterConfig configures a RateLimiter
+type RateLimiterConfig struct {
+	Default  RateLimit                         // Limit of the levels without an entry in Levels
+	Levels   map[core.Level]RateLimit          // Limits per level
+	Key      func(entry *core.LogEntry) string // Bucket of an entry within its level, defaults to KeyByCaller
+	ByCaller bool                              // Key reads LogEntry.PC, so the logger must set it; implied when Key is nil
+	MaxKeys  int                               // Buckets kept, least recently used ones are evicted first; defaults to DEFAULT_RATE_LIMIT_KEYS
+}
+
+// RateLimiter limits the entries written per level and key, e.g. per call site or
+// per tenant, with token buckets. The number of entries dropped from a bucket is
+// added to the next entry it writes as the SUPPRESSED_KEY field. Buckets are kept in
+// a bounded LRU, so an evicted bucket starts full and its suppressed count is lost.
+type RateLimiter struct {
+	limits  [numLevels]RateLimit
+	key     func(*core.LogEntry) string
+	caller  bool // The key uses the call site, see UsesCaller
+	byPC    bool // The key is KeyByCaller, so entries with a call site are keyed by LogEntry.PC
+	maxKeys int
+	mu      sync.Mutex
+	buckets map[rateKey]*list.Element
+	lru     *list.List // Front is the most recently used bucket
+	dropped atomic.Uint64
+}
+
+// rateKey identifies a bucket. Entries keyed by KeyByCaller use pc instead of the
+// string key, so sampling them does not build a string.
+type rateKey struct {
+	level core.Level
+	pc    uintptr
+	key   string
+}
+
+// bucket is the token bucket of a key
+type bucket struct {
+	key        rateKey
+	tokens     float64
+	last       time.Time // Time the tokens were last refilled
+	suppressed uint64    // Entries dropped since the last written entry
+}
+
+// NewRateLimiter creates a RateLimiter
+func NewRateLimiter(config RateLimiterConfig) *RateLimiter {
+	r := &RateLimiter{
+		key:     config.Key,
+		caller:  config.ByCaller,
+		maxKeys: config.MaxKeys,
+		buckets: make(map[rateKey]*list.Element),
+		lru:     list.New(),
+	}
+	if r.key == nil {
+		r.key, r.caller = KeyByCaller, true
+	}
+	r.byPC = reflect.ValueOf(r.key).Pointer() == reflect.ValueOf(KeyByCaller).Pointer()
+	if r.maxKeys <= 0 {
+		r.maxKeys = DEFAULT_RATE_LIMIT_KEYS
+	}
+	for i := range r.limits {
+		limit, ok := config.Levels[core.Level(i)]
+		if !ok {
+			limit = config.Default
+		}
+		if limit.Rate > 0 && limit.Burst <= 0 {
+			limit.Burst = int(math.Ceil(limit.Rate))
+		}
+		r.limits[i] = limit
+	}
+	return r
+}
+
+// Sample implements Sampler. Tokens are refilled with the entry timestamps.
+func (r *RateLimiter) Sample(entry *core.LogEntry) bool {
+	level := entry.Level
+	if level < core.TRACE || level > core.PANIC {
+		return true
+	}
+	limit := r.limits[level]
+	if limit.Rate <= 0 {
+		return true
+	}
+
+	k := rateKey{level: level}
+	if r.byPC && entry.PC != 0 {
+		k.pc = entry.PC
+	} else {
+		k.key = r.key(entry)
+	}
+	now := entry.Timestamp
+
+	r.mu.Lock()
+	b := r.bucket(k, limit, now)
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed.Seconds()*limit.Rate)
+		b.last = now
+	}
+	if b.tokens < 1 {
+		b.suppressed++
+		r.mu.Unlock()
+		r.dropped.Add(1)
+		return false
+	}
+	b.tokens--
+	suppressed := b.suppressed
+	b.suppressed = 0
+	r.mu.Unlock()
+
+	if suppressed > 0 {
+		putField(entry, core.Uint64(SUPPRESSED_KEY, suppressed))
+	}
+	return true
+}
+
+// putField stores a typed field in the entry, replacing a field with the same key
+func putField(entry *core.LogEntry, f core.Field) {
+	delete(entry.Fields, f.Key)
+	for i := range entry.TypedFields {
+		if entry.TypedFields[i].Key == f.Key {
+			entry.TypedFields[i] = f
+			return
+		}
+	}
+	entry.TypedFields = append(entry.TypedFields, f)
+}
+
+// bucket returns the bucket of k, creating a full one and evicting the least
+// recently used bucket if needed. r.mu must be held.
+func (r *RateLimiter) bucket(k rateKey, limit RateLimit, now time.Time) *bucket {
+	if e, ok := r.buckets[k]; ok {
+		r.lru.MoveToFront(e)
+		return e.Value.(*bucket)
+	}
+	if r.lru.Len() >= r.maxKeys {
+		oldest := r.lru.Back()
+		r.lru.Remove(oldest)
+		delete(r.buckets, oldest.Value.(*bucket).key)
+	}
+	b := &bucket{key: k, tokens: float64(limit.Burst), last: now}
+	r.buckets[k] = r.lru.PushFront(b)
+	return b
+}
+
+// Dropped returns the number of entries dropped so far
+func (r *RateLimiter) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// Len returns the number of buckets kept
+func (r *RateLimiter) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+// UsesCaller implements CallerSampler
+func (r *RateLimiter) UsesCaller() bool {
+	return r.caller
+}
+
+// KeyByCaller keys entries by their call site, the program counter the logger sets
+// in LogEntry.PC. Entries without it, e.g. built outside a logger, are keyed by
+// their message. Set RateLimiterConfig.ByCaller when passing it explicitly. A
+// RateLimiter using it keys entries by the program counter itself without calling it.
+func KeyByCaller(entry *core.LogEntry) string {
+	if entry.PC != 0 {
+		return strconv.FormatUint(uint64(entry.PC), 16)
+	}
+	return string(entry.Message)
+}
+
+// KeyByField returns a key function that keys entries by the value of the field
+// name, e.g. "tenant_id". Entries without the field share one bucket.
+func KeyByField(name string) func(entry *core.LogEntry) string {
+	return func(entry *core.LogEntry) string {
+		return fieldString(entry, name)
+	}
+}
+
+// fieldString returns the value of the field name of entry as a string, or "" if
+// the entry does not have it. Typed fields are searched last to first.
+func fieldString(entry *core.LogEntry, name string) string {
+	for i := len(entry.TypedFields) - 1; i >= 0; i-- {
+		if f := &entry.TypedFields[i]; f.Key == name {
+			switch f.Type {
+			case core.StringType:
+				return f.String
+			case core.BytesType:
+				return string(f.Bytes)
+			default:
+				var buf bytes.Buffer
+				f.WriteValue(&buf)
+				return buf.String()
+			}
+		}
+	}
+	if v, ok := entry.Fields[name]; ok {
+		return string(v)
+	}
+	return ""
+}
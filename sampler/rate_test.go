@@ -0,0 +1,203 @@
+package sampler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// rateEntry creates an entry with a call site and a tenant field
+func rateEntry(level core.Level, site uintptr, tenant string, ts time.Time) *core.LogEntry {
+	return &core.LogEntry{
+		Level:       level,
+		Message:     []byte("request"),
+		Timestamp:   ts,
+		PC:          site,
+		TypedFields: []core.Field{core.String("tenant_id", tenant)},
+	}
+}
+
+// TestRateLimiterBurstAndRefill tests the token bucket of one call site
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	r := NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 10, Burst: 5}})
+	start := time.Now()
+
+	kept := 0
+	for i := 0; i < 20; i++ {
+		if r.Sample(rateEntry(core.INFO, 1, "", start)) {
+			kept++
+		}
+	}
+	if kept != 5 {
+		t.Errorf("Expected the burst of 5 entries, got %d", kept)
+	}
+
+	// 100ms refill one token at 10 entries per second
+	entry := rateEntry(core.INFO, 1, "", start.Add(100*time.Millisecond))
+	if !r.Sample(entry) {
+		t.Fatal("Expected a refilled token")
+	}
+	last := entry.TypedFields[len(entry.TypedFields)-1]
+	if last.Key != SUPPRESSED_KEY || last.Value() != uint64(15) {
+		t.Errorf("Expected the suppressed count 15 on the next entry, got %v=%v", last.Key, last.Value())
+	}
+	if r.Sample(rateEntry(core.INFO, 1, "", start.Add(100*time.Millisecond))) {
+		t.Error("Expected the bucket to be empty again")
+	}
+	if r.Dropped() != 16 {
+		t.Errorf("Expected 16 dropped entries, got %d", r.Dropped())
+	}
+}
+
+// TestRateLimiterKeys tests separate buckets per call site, field and level
+func TestRateLimiterKeys(t *testing.T) {
+	now := time.Now()
+	byCaller := NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 1}})
+	if !byCaller.Sample(rateEntry(core.INFO, 1, "a", now)) || !byCaller.Sample(rateEntry(core.INFO, 2, "a", now)) {
+		t.Error("Expected a bucket per call site")
+	}
+	if !byCaller.Sample(rateEntry(core.WARN, 1, "a", now)) {
+		t.Error("Expected a bucket per level")
+	}
+	if byCaller.Sample(rateEntry(core.INFO, 1, "b", now)) {
+		t.Error("Expected the tenant to be ignored when keying by call site")
+	}
+
+	byTenant := NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 1}, Key: KeyByField("tenant_id")})
+	if !byTenant.Sample(rateEntry(core.INFO, 1, "a", now)) || !byTenant.Sample(rateEntry(core.INFO, 1, "b", now)) {
+		t.Error("Expected a bucket per tenant")
+	}
+	if byTenant.Sample(rateEntry(core.INFO, 2, "a", now)) {
+		t.Error("Expected the call site to be ignored when keying by tenant")
+	}
+}
+
+// TestRateLimiterSuppressedReplaced tests that the suppressed count replaces a field
+// with the same key instead of adding a second one
+func TestRateLimiterSuppressedReplaced(t *testing.T) {
+	r := NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 1}})
+	start := time.Now()
+	r.Sample(rateEntry(core.INFO, 1, "", start))
+	r.Sample(rateEntry(core.INFO, 1, "", start))
+
+	entry := rateEntry(core.INFO, 1, "", start.Add(time.Second))
+	entry.TypedFields = append(entry.TypedFields, core.String(SUPPRESSED_KEY, "caller"))
+	entry.Fields = map[string][]byte{SUPPRESSED_KEY: []byte("caller")}
+	if !r.Sample(entry) {
+		t.Fatal("Expected a refilled token")
+	}
+
+	count := 0
+	for _, f := range entry.TypedFields {
+		if f.Key == SUPPRESSED_KEY {
+			count++
+			if f.Value() != uint64(1) {
+				t.Errorf("Expected the suppressed count 1, got %v", f.Value())
+			}
+		}
+	}
+	if _, ok := entry.Fields[SUPPRESSED_KEY]; ok || count != 1 {
+		t.Errorf("Expected one %s field, got %d typed fields and %v", SUPPRESSED_KEY, count, entry.Fields)
+	}
+}
+
+// TestRateLimiterCallerAllocs tests that keying by call site does not allocate
+func TestRateLimiterCallerAllocs(t *testing.T) {
+	for name, r := range map[string]*RateLimiter{
+		"default":            NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 1}}),
+		"explicit by caller": NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 1}, Key: KeyByCaller, ByCaller: true}),
+	} {
+		entry := rateEntry(core.INFO, 1, "", time.Now())
+		r.Sample(entry)
+		if allocs := testing.AllocsPerRun(100, func() { r.Sample(entry) }); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %v", name, allocs)
+		}
+	}
+}
+
+// TestRateLimiterLevels tests per level limits
+func TestRateLimiterLevels(t *testing.T) {
+	r := NewRateLimiter(RateLimiterConfig{
+		Default: RateLimit{Rate: 1},
+		Levels: map[core.Level]RateLimit{
+			core.ERROR: {},                  // Unlimited
+			core.DEBUG: {Rate: 2, Burst: 3}, // Larger burst
+		},
+	})
+	now := time.Now()
+	count := func(level core.Level) int {
+		kept := 0
+		for i := 0; i < 10; i++ {
+			if r.Sample(rateEntry(level, 1, "", now)) {
+				kept++
+			}
+		}
+		return kept
+	}
+
+	if got := count(core.INFO); got != 1 {
+		t.Errorf("Expected the default burst of 1, got %d", got)
+	}
+	if got := count(core.DEBUG); got != 3 {
+		t.Errorf("Expected the DEBUG burst of 3, got %d", got)
+	}
+	if got := count(core.ERROR); got != 10 {
+		t.Errorf("Expected ERROR to be unlimited, got %d", got)
+	}
+}
+
+// TestRateLimiterLRU tests that the number of buckets is bounded
+func TestRateLimiterLRU(t *testing.T) {
+	r := NewRateLimiter(RateLimiterConfig{Default: RateLimit{Rate: 1}, MaxKeys: 2})
+	now := time.Now()
+
+	r.Sample(rateEntry(core.INFO, 1, "", now))
+	r.Sample(rateEntry(core.INFO, 2, "", now))
+	r.Sample(rateEntry(core.INFO, 1, "", now)) // Site 1 becomes the most recently used
+	r.Sample(rateEntry(core.INFO, 3, "", now)) // Evicts site 2
+
+	if r.Len() != 2 {
+		t.Errorf("Expected 2 buckets, got %d", r.Len())
+	}
+	if !r.Sample(rateEntry(core.INFO, 2, "", now)) {
+		t.Error("Expected the evicted bucket to start full")
+	}
+	if r.Sample(rateEntry(core.INFO, 3, "", now)) {
+		t.Error("Expected the recently used bucket to be kept")
+	}
+}
+
+// TestKeyByCallerWithoutCaller tests keying entries without call site by message
+func TestKeyByCallerWithoutCaller(t *testing.T) {
+	if got := KeyByCaller(&core.LogEntry{Message: []byte("msg")}); got != "msg" {
+		t.Errorf("Expected the message as key, got %q", got)
+	}
+	entry := &core.LogEntry{TypedFields: []core.Field{core.Int("tenant_id", 7)}}
+	if got := KeyByField("tenant_id")(entry); got != "7" {
+		t.Errorf("Expected the printed field value, got %q", got)
+	}
+	entry = &core.LogEntry{Fields: map[string][]byte{"tenant_id": []byte("acme")}}
+	if got := KeyByField("tenant_id")(entry); got != "acme" {
+		t.Errorf("Expected the byte field value, got %q", got)
+	}
+}
+
+// TestRateLimiterUsesCaller tests reporting whether the logger must set the call site
+func TestRateLimiterUsesCaller(t *testing.T) {
+	byField := NewRateLimiter(RateLimiterConfig{Key: KeyByField("tenant_id")})
+	for name, tc := range map[string]struct {
+		s    Sampler
+		want bool
+	}{
+		"default key":        {NewRateLimiter(RateLimiterConfig{}), true},
+		"explicit by caller": {NewRateLimiter(RateLimiterConfig{Key: KeyByCaller, ByCaller: true}), true},
+		"by field":           {byField, false},
+		"chain":              {Chain(byField, NewRateLimiter(RateLimiterConfig{})), true},
+		"chain without":      {Chain(byField, NewHashSampler(HashSamplerConfig{Probability: 0.5})), false},
+	} {
+		if got := tc.s.(CallerSampler).UsesCaller(); got != tc.want {
+			t.Errorf("%s: expected UsesCaller %v, got %v", name, tc.want, got)
+		}
+	}
+}
@@ -43,3 +43,34 @@ func (sl *SamplingLogger) Log(ctx context.Context, level core.Level, msg []byte,
         sl.processor.Log(ctx, level, msg, fields)
     }
 }
+
+// Sampler decides whether a built entry is written. Unlike SamplingLogger, which
+// only counts calls, a Sampler sees the level, message, fields and context data of
+// the entry. Sample is called concurrently and may add fields to entries it keeps.
+// The logger always writes FATAL and PANIC entries and only passes them to samplers
+// that implement Releaser.
+type Sampler interface {
+	Sample(entry *core.LogEntry) bool
+}
+
+// Releaser is implemented by samplers that hold entries back and write them later,
+// such as TailSampler. The logger passes the function that writes a released entry
+// without sampling it again; a sampler shared by several loggers releases through
+// the logger it was last given to.
+type Releaser interface {
+	SetRelease(release func(entry *core.LogEntry))
+}
+
+// RateReporter is implemented by samplers whose keep ratio changes over time, such as
+// AdaptiveSampler. Rates returns the fraction of entries currently kept per level;
+// the logger reports it in its LoggerStats.
+type RateReporter interface {
+	Rates() map[core.Level]float64
+}
+
+// CallerSampler is implemented by samplers that key entries by their call site, such
+// as a RateLimiter keyed with KeyByCaller. UsesCaller reports whether the logger must
+// set LogEntry.PC, which costs a stack walk per entry.
+type CallerSampler interface {
+	UsesCaller() bool
+}
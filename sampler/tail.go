@@ -0,0 +1,206 @@
+package sampler
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// DEFAULT_TAIL_TIMEOUT is how long a TailSampler keeps a trace by default
+const DEFAULT_TAIL_TIMEOUT = 10 * time.Second
+
+// DEFAULT_TAIL_MAX_TRACES is the number of traces a TailSampler keeps by default
+const DEFAULT_TAIL_MAX_TRACES = 1000
+
+// DEFAULT_TAIL_MAX_ENTRIES is the number of entries a TailSampler keeps per trace by default
+const DEFAULT_TAIL_MAX_ENTRIES = 100
+
+// TailSamplerConfig configures a TailSampler
+type TailSamplerConfig struct {
+	Levels             []core.Level  // Levels held back per trace, defaults to TRACE and DEBUG
+	Timeout            time.Duration // How long a trace is kept after its first entry, defaults to DEFAULT_TAIL_TIMEOUT
+	MaxTraces          int           // Traces kept at once, the oldest one is discarded first; defaults to DEFAULT_TAIL_MAX_TRACES
+	MaxEntriesPerTrace int           // Entries kept per trace, the oldest one is discarded first; defaults to DEFAULT_TAIL_MAX_ENTRIES
+}
+
+// TailStats reports what a TailSampler did with the traces it saw
+type TailStats struct {
+	FlushedTraces    uint64 // Traces written because of an ERROR or higher entry
+	DiscardedTraces  uint64 // Traces dropped at the timeout without an error
+	EvictedTraces    uint64 // Traces dropped before the timeout because MaxTraces was reached
+	FlushedEntries   uint64 // Held entries written
+	DiscardedEntries uint64 // Held entries dropped, including the ones over MaxEntriesPerTrace
+	PendingTraces    int    // Traces currently kept
+	PendingEntries   int    // Entries currently held
+}
+
+// TailSampler holds entries of the configured levels back per trace ID, see
+// LogEntry.TraceID. When an ERROR or higher entry of the trace is logged before the
+// timeout, the held entries are written before it and later entries of the trace
+// are written directly; otherwise they are dropped. Entries without a trace ID and
+// of other levels are kept. The logger must be at the lowest held level for the
+// entries to reach the sampler. Held entries are copied, so memory is bounded by
+// MaxTraces times MaxEntriesPerTrace entries.
+type TailSampler struct {
+	held       uint32 // Bit set of held levels
+	timeout    time.Duration
+	maxTraces  int
+	maxEntries int
+	release    atomic.Pointer[func(*core.LogEntry)]
+
+	mu      sync.Mutex
+	traces  map[string]*list.Element
+	order   *list.List // Traces by first entry, front is the oldest
+	pending int        // Entries currently held
+	stats   TailStats
+}
+
+// tailTrace holds the entries of one trace
+type tailTrace struct {
+	id      string
+	started time.Time
+	entries []*core.LogEntry
+	failed  bool // An ERROR or higher entry was logged, entries are written directly
+}
+
+// NewTailSampler creates a TailSampler
+func NewTailSampler(config TailSamplerConfig) *TailSampler {
+	s := &TailSampler{
+		timeout:    config.Timeout,
+		maxTraces:  config.MaxTraces,
+		maxEntries: config.MaxEntriesPerTrace,
+		traces:     make(map[string]*list.Element),
+		order:      list.New(),
+	}
+	if s.timeout <= 0 {
+		s.timeout = DEFAULT_TAIL_TIMEOUT
+	}
+	if s.maxTraces <= 0 {
+		s.maxTraces = DEFAULT_TAIL_MAX_TRACES
+	}
+	if s.maxEntries <= 0 {
+		s.maxEntries = DEFAULT_TAIL_MAX_ENTRIES
+	}
+	levels := config.Levels
+	if len(levels) == 0 {
+		levels = []core.Level{core.TRACE, core.DEBUG}
+	}
+	for _, level := range levels {
+		if level >= core.TRACE && level < core.ERROR {
+			s.held |= 1 << uint(level)
+		}
+	}
+	return s
+}
+
+// SetRelease implements Releaser
+func (s *TailSampler) SetRelease(release func(entry *core.LogEntry)) {
+	s.release.Store(&release)
+}
+
+// Sample implements Sampler. Traces expire by the entry timestamps.
+func (s *TailSampler) Sample(entry *core.LogEntry) bool {
+	if len(entry.TraceID) == 0 {
+		return true
+	}
+	held := entry.Level >= core.TRACE && entry.Level <= core.PANIC && s.held&(1<<uint(entry.Level)) != 0
+	if !held && entry.Level < core.ERROR {
+		return true
+	}
+
+	s.mu.Lock()
+	s.expire(entry.Timestamp)
+	var t *tailTrace
+	if e, ok := s.traces[string(entry.TraceID)]; ok {
+		t = e.Value.(*tailTrace)
+	}
+
+	if held {
+		if t != nil && t.failed {
+			s.mu.Unlock()
+			return true
+		}
+		if t == nil {
+			t = s.add(entry)
+		}
+		if len(t.entries) >= s.maxEntries {
+			copy(t.entries, t.entries[1:])
+			t.entries = t.entries[:len(t.entries)-1]
+			s.pending--
+			s.stats.DiscardedEntries++
+		}
+		t.entries = append(t.entries, entry.Clone())
+		s.pending++
+		s.mu.Unlock()
+		return false
+	}
+
+	// An ERROR or higher entry flushes the trace
+	var flush []*core.LogEntry
+	if t == nil {
+		t = s.add(entry)
+	}
+	if !t.failed {
+		t.failed = true
+		flush = t.entries
+		t.entries = nil
+		s.pending -= len(flush)
+		s.stats.FlushedTraces++
+		s.stats.FlushedEntries += uint64(len(flush))
+	}
+	s.mu.Unlock()
+
+	if release := s.release.Load(); release != nil {
+		for _, held := range flush {
+			(*release)(held)
+		}
+	}
+	return true
+}
+
+// add starts keeping the trace of entry, discarding the oldest trace if MaxTraces
+// is reached. s.mu must be held.
+func (s *TailSampler) add(entry *core.LogEntry) *tailTrace {
+	if s.order.Len() >= s.maxTraces {
+		if t := s.remove(s.order.Front()); !t.failed {
+			s.stats.EvictedTraces++
+		}
+	}
+	t := &tailTrace{id: string(entry.TraceID), started: entry.Timestamp}
+	s.traces[t.id] = s.order.PushBack(t)
+	return t
+}
+
+// expire drops the traces whose timeout has passed at now. s.mu must be held.
+func (s *TailSampler) expire(now time.Time) {
+	for e := s.order.Front(); e != nil; e = s.order.Front() {
+		if now.Sub(e.Value.(*tailTrace).started) < s.timeout {
+			return
+		}
+		if t := s.remove(e); !t.failed {
+			s.stats.DiscardedTraces++
+		}
+	}
+}
+
+// remove stops keeping a trace and drops its held entries. s.mu must be held.
+func (s *TailSampler) remove(e *list.Element) *tailTrace {
+	t := s.order.Remove(e).(*tailTrace)
+	delete(s.traces, t.id)
+	s.pending -= len(t.entries)
+	s.stats.DiscardedEntries += uint64(len(t.entries))
+	return t
+}
+
+// Stats returns what the sampler did with the traces so far
+func (s *TailSampler) Stats() TailStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.PendingTraces = s.order.Len()
+	stats.PendingEntries = s.pending
+	return stats
+}
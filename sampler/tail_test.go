@@ -0,0 +1,123 @@
+package sampler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// traceEntry creates an entry of a trace
+func traceEntry(level core.Level, trace, msg string, ts time.Time) *core.LogEntry {
+	return &core.LogEntry{Level: level, TraceID: []byte(trace), Message: []byte(msg), Timestamp: ts}
+}
+
+// releaseRecorder collects the messages of released entries
+type releaseRecorder struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (r *releaseRecorder) release(entry *core.LogEntry) {
+	r.mu.Lock()
+	r.messages = append(r.messages, string(entry.Message))
+	r.mu.Unlock()
+}
+
+// newTestTailSampler creates a tail sampler releasing into a recorder
+func newTestTailSampler(config TailSamplerConfig) (*TailSampler, *releaseRecorder) {
+	s := NewTailSampler(config)
+	rec := &releaseRecorder{}
+	s.SetRelease(rec.release)
+	return s, rec
+}
+
+// TestTailSamplerFlushOnError tests writing the held entries of a failed trace
+func TestTailSamplerFlushOnError(t *testing.T) {
+	s, rec := newTestTailSampler(TailSamplerConfig{})
+	now := time.Now()
+
+	if s.Sample(traceEntry(core.DEBUG, "a", "step 1", now)) || s.Sample(traceEntry(core.TRACE, "a", "step 2", now)) {
+		t.Fatal("Expected DEBUG and TRACE entries to be held")
+	}
+	if !s.Sample(traceEntry(core.INFO, "a", "info", now)) || !s.Sample(traceEntry(core.DEBUG, "", "no trace", now)) {
+		t.Fatal("Expected INFO entries and entries without trace to be kept")
+	}
+	s.Sample(traceEntry(core.DEBUG, "b", "other trace", now))
+
+	entry := traceEntry(core.ERROR, "a", "failed", now)
+	entry.Message[0] = 'F' // The held entries are copies
+	if !s.Sample(entry) {
+		t.Fatal("Expected the ERROR entry to be kept")
+	}
+	if len(rec.messages) != 2 || rec.messages[0] != "step 1" || rec.messages[1] != "step 2" {
+		t.Errorf("Expected the held entries in order, got %q", rec.messages)
+	}
+	if !s.Sample(traceEntry(core.DEBUG, "a", "after the error", now)) {
+		t.Error("Expected entries of a failed trace to be written directly")
+	}
+
+	stats := s.Stats()
+	if stats.FlushedTraces != 1 || stats.FlushedEntries != 2 || stats.PendingTraces != 2 || stats.PendingEntries != 1 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestTailSamplerTimeout tests dropping traces without an error
+func TestTailSamplerTimeout(t *testing.T) {
+	s, rec := newTestTailSampler(TailSamplerConfig{Timeout: time.Second})
+	start := time.Now()
+
+	s.Sample(traceEntry(core.DEBUG, "a", "held", start))
+	s.Sample(traceEntry(core.DEBUG, "a", "held too", start.Add(500*time.Millisecond)))
+	s.Sample(traceEntry(core.ERROR, "a", "too late", start.Add(time.Second)))
+
+	if len(rec.messages) != 0 {
+		t.Errorf("Expected the expired trace not to be written, got %q", rec.messages)
+	}
+	if stats := s.Stats(); stats.DiscardedTraces != 1 || stats.DiscardedEntries != 2 || stats.FlushedTraces != 1 || stats.FlushedEntries != 0 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+// TestTailSamplerLimits tests the memory caps
+func TestTailSamplerLimits(t *testing.T) {
+	s, rec := newTestTailSampler(TailSamplerConfig{MaxTraces: 2, MaxEntriesPerTrace: 2})
+	now := time.Now()
+
+	for _, msg := range []string{"1", "2", "3"} {
+		s.Sample(traceEntry(core.DEBUG, "a", msg, now))
+	}
+	s.Sample(traceEntry(core.DEBUG, "b", "b", now))
+	s.Sample(traceEntry(core.DEBUG, "c", "c", now)) // Evicts trace a
+
+	stats := s.Stats()
+	if stats.EvictedTraces != 1 || stats.DiscardedEntries != 3 || stats.PendingTraces != 2 || stats.PendingEntries != 2 {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+
+	s.Sample(traceEntry(core.ERROR, "a", "failed", now))
+	if len(rec.messages) != 0 {
+		t.Errorf("Expected nothing from the evicted trace, got %q", rec.messages)
+	}
+	s.Sample(traceEntry(core.ERROR, "c", "failed", now))
+	if len(rec.messages) != 1 || rec.messages[0] != "c" {
+		t.Errorf("Expected the kept trace to be flushed, got %q", rec.messages)
+	}
+}
+
+// TestTailSamplerLevels tests holding other levels
+func TestTailSamplerLevels(t *testing.T) {
+	s := NewTailSampler(TailSamplerConfig{Levels: []core.Level{core.INFO, core.ERROR}})
+	now := time.Now()
+	if s.Sample(traceEntry(core.INFO, "a", "held", now)) {
+		t.Error("Expected INFO to be held")
+	}
+	if !s.Sample(traceEntry(core.DEBUG, "a", "kept", now)) {
+		t.Error("Expected DEBUG to be kept")
+	}
+	if !s.Sample(traceEntry(core.ERROR, "a", "error", now)) {
+		t.Error("Expected ERROR never to be held")
+	}
+}
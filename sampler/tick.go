@@ -0,0 +1,135 @@
+package sampler
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// DEFAULT_TICK is the interval after which a TickSampler restarts its counters by default
+const DEFAULT_TICK = time.Second
+
+// DEFAULT_TICK_COUNTERS is the number of counters per level of a TickSampler by default
+const DEFAULT_TICK_COUNTERS = 1024
+
+// numLevels is the number of log levels, TRACE to PANIC
+const numLevels = int(core.PANIC) + 1
+
+// TickSamplerConfig configures a TickSampler
+type TickSamplerConfig struct {
+	Tick       time.Duration                              // Interval after which the counters restart, defaults to DEFAULT_TICK
+	First      int                                        // Entries written per level and message in each tick before sampling starts
+	Thereafter int                                        // After First, every Thereafter-th entry is written; 0 drops the rest of the tick
+	Exempt     []core.Level                               // Levels that are never sampled, e.g. ERROR
+	Counters   int                                        // Counters per level, rounded up to a power of two, defaults to DEFAULT_TICK_COUNTERS
+	OnDrop     func(entry *core.LogEntry, dropped uint64) // Called for every dropped entry with the number of entries dropped so far
+}
+
+// TickSampler writes, for every level and message, the first entries of each tick
+// and then every Thereafter-th one. Messages are hashed into a fixed table of
+// counters, so memory stays bounded however many distinct messages are logged;
+// messages sharing a counter are sampled together.
+type TickSampler struct {
+	tick       int64
+	first      uint64
+	thereafter uint64
+	exempt     uint32 // Bit set of exempt levels
+	mask       uint32
+	counters   [numLevels][]tickCounter
+	dropped    atomic.Uint64
+	onDrop     func(*core.LogEntry, uint64)
+}
+
+// tickCounter counts the entries of one key in the current tick
+type tickCounter struct {
+	resetAt atomic.Int64 // Unix nanoseconds at which the tick ends
+	count   atomic.Uint64
+}
+
+// NewTickSampler creates a TickSampler
+func NewTickSampler(config TickSamplerConfig) *TickSampler {
+	tick := config.Tick
+	if tick <= 0 {
+		tick = DEFAULT_TICK
+	}
+	size := config.Counters
+	if size <= 0 {
+		size = DEFAULT_TICK_COUNTERS
+	}
+	n := 1
+	for n < size {
+		n <<= 1
+	}
+
+	s := &TickSampler{
+		tick:   tick.Nanoseconds(),
+		mask:   uint32(n - 1),
+		onDrop: config.OnDrop,
+	}
+	if config.First > 0 {
+		s.first = uint64(config.First)
+	}
+	if config.Thereafter > 0 {
+		s.thereafter = uint64(config.Thereafter)
+	}
+	for _, level := range config.Exempt {
+		if level >= core.TRACE && level <= core.PANIC {
+			s.exempt |= 1 << uint(level)
+		}
+	}
+	for i := range s.counters {
+		s.counters[i] = make([]tickCounter, n)
+	}
+	return s
+}
+
+// Sample implements Sampler. The tick is measured with the entry timestamps.
+func (s *TickSampler) Sample(entry *core.LogEntry) bool {
+	level := entry.Level
+	if level < core.TRACE || level > core.PANIC || s.exempt&(1<<uint(level)) != 0 {
+		return true
+	}
+
+	c := &s.counters[level][hashBytes(entry.Message)&s.mask]
+	n := c.incCheckReset(entry.Timestamp.UnixNano(), s.tick)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		return true
+	}
+
+	dropped := s.dropped.Add(1)
+	if s.onDrop != nil {
+		s.onDrop(entry, dropped)
+	}
+	return false
+}
+
+// Dropped returns the number of entries dropped so far
+func (s *TickSampler) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// incCheckReset counts an entry at now and returns its number in the current tick,
+// starting a new tick if the current one has ended
+func (c *tickCounter) incCheckReset(now, tick int64) uint64 {
+	resetAt := c.resetAt.Load()
+	if now < resetAt {
+		return c.count.Add(1)
+	}
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick) {
+		// Another goroutine started the tick
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// hashBytes returns the 32-bit FNV-1a hash of b
+func hashBytes(b []byte) uint32 {
+	h := uint32(2166136261)
+	for _, c := range b {
+		h ^= uint32(c)
+		h *= 16777619
+	}
+	return h
+}
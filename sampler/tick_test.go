@@ -0,0 +1,147 @@
+package sampler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Lunar-Chipter/mire/core"
+)
+
+// tickEntry creates an entry for the tick sampler tests
+func tickEntry(level core.Level, msg string, ts time.Time) *core.LogEntry {
+	return &core.LogEntry{Level: level, Message: []byte(msg), Timestamp: ts}
+}
+
+// countKept returns how many of n entries the sampler keeps
+func countKept(s Sampler, n int, level core.Level, msg string, ts time.Time) int {
+	kept := 0
+	for i := 0; i < n; i++ {
+		if s.Sample(tickEntry(level, msg, ts)) {
+			kept++
+		}
+	}
+	return kept
+}
+
+// TestTickSamplerFirstThereafter tests the first N and every Mth entry per key
+func TestTickSamplerFirstThereafter(t *testing.T) {
+	s := NewTickSampler(TickSamplerConfig{Tick: time.Minute, First: 3, Thereafter: 5})
+	now := time.Now()
+
+	// Entries 1-3 and then 8, 13, 18
+	if got := countKept(s, 20, core.INFO, "request", now); got != 6 {
+		t.Errorf("Expected 6 of 20 entries, got %d", got)
+	}
+	if got := countKept(s, 3, core.INFO, "other message", now); got != 3 {
+		t.Errorf("Expected another message to have its own counter, got %d", got)
+	}
+	if got := countKept(s, 3, core.WARN, "request", now); got != 3 {
+		t.Errorf("Expected another level to have its own counter, got %d", got)
+	}
+	if s.Dropped() != 14 {
+		t.Errorf("Expected 14 dropped entries, got %d", s.Dropped())
+	}
+}
+
+// TestTickSamplerDropRest tests dropping everything after First when Thereafter is 0
+func TestTickSamplerDropRest(t *testing.T) {
+	s := NewTickSampler(TickSamplerConfig{Tick: time.Minute, First: 2})
+	if got := countKept(s, 10, core.DEBUG, "noisy", time.Now()); got != 2 {
+		t.Errorf("Expected 2 of 10 entries, got %d", got)
+	}
+}
+
+// TestTickSamplerReset tests that counters restart with a new tick
+func TestTickSamplerReset(t *testing.T) {
+	s := NewTickSampler(TickSamplerConfig{Tick: time.Second, First: 1})
+	start := time.Now()
+
+	if got := countKept(s, 5, core.INFO, "msg", start); got != 1 {
+		t.Errorf("Expected 1 entry in the first tick, got %d", got)
+	}
+	if got := countKept(s, 5, core.INFO, "msg", start.Add(500*time.Millisecond)); got != 0 {
+		t.Errorf("Expected no entries later in the first tick, got %d", got)
+	}
+	if got := countKept(s, 5, core.INFO, "msg", start.Add(time.Second)); got != 1 {
+		t.Errorf("Expected 1 entry in the second tick, got %d", got)
+	}
+}
+
+// TestTickSamplerExempt tests that exempt levels are never dropped
+func TestTickSamplerExempt(t *testing.T) {
+	s := NewTickSampler(TickSamplerConfig{Tick: time.Minute, First: 1, Exempt: []core.Level{core.ERROR}})
+	now := time.Now()
+	if got := countKept(s, 10, core.ERROR, "failed", now); got != 10 {
+		t.Errorf("Expected every ERROR entry, got %d", got)
+	}
+	if got := countKept(s, 10, core.WARN, "failed", now); got != 1 {
+		t.Errorf("Expected WARN entries to be sampled, got %d", got)
+	}
+}
+
+// TestTickSamplerOnDrop tests the drop hook
+func TestTickSamplerOnDrop(t *testing.T) {
+	var messages []string
+	var last uint64
+	s := NewTickSampler(TickSamplerConfig{
+		Tick:  time.Minute,
+		First: 1,
+		OnDrop: func(entry *core.LogEntry, dropped uint64) {
+			messages = append(messages, string(entry.Message))
+			last = dropped
+		},
+	})
+	countKept(s, 3, core.INFO, "dropped", time.Now())
+
+	if len(messages) != 2 || messages[0] != "dropped" || last != 2 {
+		t.Errorf("Unexpected drop reports %q, last count %d", messages, last)
+	}
+}
+
+// TestTickSamplerCounters tests that the counter table is bounded
+func TestTickSamplerCounters(t *testing.T) {
+	s := NewTickSampler(TickSamplerConfig{Counters: 100})
+	if len(s.counters[core.INFO]) != 128 {
+		t.Errorf("Expected 128 counters per level, got %d", len(s.counters[core.INFO]))
+	}
+	if d := NewTickSampler(TickSamplerConfig{}); len(d.counters[core.INFO]) != DEFAULT_TICK_COUNTERS || d.tick != DEFAULT_TICK.Nanoseconds() {
+		t.Errorf("Unexpected defaults %d counters, tick %d", len(d.counters[core.INFO]), d.tick)
+	}
+}
+
+// TestTickSamplerConcurrent tests that concurrent entries of one tick are counted once each
+func TestTickSamplerConcurrent(t *testing.T) {
+	s := NewTickSampler(TickSamplerConfig{Tick: time.Minute, First: 10, Thereafter: 10})
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	kept := 0
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n := countKept(s, 100, core.INFO, "concurrent", now)
+			mu.Lock()
+			kept += n
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// 10 first entries and every 10th of the remaining 790
+	if kept != 89 {
+		t.Errorf("Expected 89 of 800 entries, got %d", kept)
+	}
+}
+
+// BenchmarkTickSampler measures sampling a built entry
+func BenchmarkTickSampler(b *testing.B) {
+	s := NewTickSampler(TickSamplerConfig{First: 100, Thereafter: 100})
+	entry := tickEntry(core.INFO, "benchmark message", time.Now())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		s.Sample(entry)
+	}
+}
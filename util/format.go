@@ -79,13 +79,9 @@ func FormatValue(buf *bytes.Buffer, value interface{}, maxWidth int) {
 }
 
 func FormatTimestamp(buf *bytes.Buffer, t time.Time, format string) {
-	// Use a pooled byte slice for intermediate conversions
-	tempBuf := GetSmallByteSliceFromPool()
-	defer PutSmallByteSliceToPool(tempBuf)
-
-	// Format the timestamp to the temp buffer
-	tsBytes := t.AppendFormat(tempBuf[:0], format)
-	buf.Write(tsBytes)
+	// Format into a stack buffer, putting a slice back into a sync.Pool allocates
+	var tempBuf [SmallByteSliceSize]byte
+	buf.Write(t.AppendFormat(tempBuf[:0], format))
 }
 
 // convertValueToString manually converts common types to string without fmt
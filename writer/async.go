@@ -21,6 +21,17 @@ type LogProcessor interface {
 	ErrOutMu() *sync.Mutex
 }
 
+// FieldLogProcessor is implemented by processors that accept typed fields in addition to []byte fields.
+// Jobs carrying typed fields are only routed to processors that implement it.
+type FieldLogProcessor interface {
+	LogFields(ctx context.Context, level core.Level, msg []byte, fields map[string][]byte, typed []core.Field)
+}
+
+// EntryLogProcessor is implemented by processors that write entries built before they were queued
+type EntryLogProcessor interface {
+	WriteEntry(entry *core.LogEntry)
+}
+
 // AsyncLogger provides asynchronous logging to reduce latency
 type AsyncLogger struct {
 	processor   LogProcessor
@@ -28,6 +39,7 @@ type AsyncLogger struct {
 	wg          sync.WaitGroup
 	workerCount int
 	closed      atomic.Bool
+	workers     atomic.Int32 // Running workers, see Flush
 	logProcessTimeout time.Duration
 	disablePerLogContextTimeout bool
 }
@@ -37,7 +49,30 @@ type logJob struct {
 	level  core.Level
 	msg    []byte
 	fields map[string][]byte
+	typed  []core.Field
+	entry  *core.LogEntry // Prebuilt entry, used instead of the other fields when set
 	ctx    context.Context
+	flush  *flushBarrier // Flush marker, used instead of the other fields when set
+}
+
+// flushBarrier is queued once per worker by Flush. A worker that takes a marker has
+// finished its previous jobs and waits until every worker took one, so at that point
+// every job queued before the markers has been processed.
+type flushBarrier struct {
+	remaining atomic.Int32  // Markers not yet taken by a worker
+	reached   chan struct{} // Closed when every marker was taken
+	abandoned chan struct{} // Closed when Flush returns, releasing waiting workers
+}
+
+// arrive records that a worker took a marker and waits for the other workers
+func (b *flushBarrier) arrive() {
+	if b.remaining.Add(-1) == 0 {
+		close(b.reached)
+	}
+	select {
+	case <-b.reached:
+	case <-b.abandoned:
+	}
 }
 
 // NewAsyncLogger creates a new AsyncLogger
@@ -52,6 +87,7 @@ func NewAsyncLogger(processor LogProcessor, workerCount int, bufferSize int, log
 
 	for i := 0; i < workerCount; i++ {
 		al.wg.Add(1)
+		al.workers.Add(1)
 		go al.worker()
 	}
 
@@ -61,6 +97,7 @@ func NewAsyncLogger(processor LogProcessor, workerCount int, bufferSize int, log
 // worker is the goroutine that processes log jobs
 func (al *AsyncLogger) worker() {
 	defer al.wg.Done()
+	defer al.workers.Add(-1)
 	defer func() {
 		if r := recover(); r != nil {
 			if al.processor.ErrOut() != nil {
@@ -86,25 +123,48 @@ func (al *AsyncLogger) worker() {
 	}()
 
 	for job := range al.logChan {
-		var ctx context.Context
-		var cancel context.CancelFunc
+		al.process(job)
+	}
+}
 
-		if al.logProcessTimeout > 0 && !al.disablePerLogContextTimeout {
-			ctx, cancel = context.WithTimeout(job.ctx, al.logProcessTimeout)
-		} else {
-			ctx = job.ctx
-		}
+// process writes a single job through the processor
+func (al *AsyncLogger) process(job *logJob) {
+	if job.flush != nil {
+		job.flush.arrive()
+		return
+	}
+	if job.entry != nil {
+		al.processor.(EntryLogProcessor).WriteEntry(job.entry)
+		return
+	}
 
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	if al.logProcessTimeout > 0 && !al.disablePerLogContextTimeout {
+		ctx, cancel = context.WithTimeout(job.ctx, al.logProcessTimeout)
+	} else {
+		ctx = job.ctx
+	}
+
+	if fp, ok := al.processor.(FieldLogProcessor); ok && len(job.typed) > 0 {
+		fp.LogFields(ctx, job.level, job.msg, job.fields, job.typed)
+	} else {
 		al.processor.Log(ctx, job.level, job.msg, job.fields)
+	}
 
-		if cancel != nil {
-			cancel()
-		}
+	if cancel != nil {
+		cancel()
 	}
 }
 
 // Log queues a log job for asynchronous processing
 func (al *AsyncLogger) Log(level core.Level, msg []byte, fields map[string][]byte, ctx context.Context) {
+	al.LogFields(level, msg, fields, nil, ctx)
+}
+
+// LogFields queues a log job carrying typed fields for asynchronous processing
+func (al *AsyncLogger) LogFields(level core.Level, msg []byte, fields map[string][]byte, typed []core.Field, ctx context.Context) {
 	// Don't try to log if logger is closed
 	if al.closed.Load() {
 		return
@@ -113,8 +173,15 @@ func (al *AsyncLogger) Log(level core.Level, msg []byte, fields map[string][]byt
 	msgCopy := make([]byte, len(msg))
 	copy(msgCopy, msg)
 
+	// The caller may reuse its field slice once we return
+	var typedCopy []core.Field
+	if len(typed) > 0 {
+		typedCopy = make([]core.Field, len(typed))
+		copy(typedCopy, typed)
+	}
+
 	select {
-	case al.logChan <- &logJob{level: level, msg: msgCopy, fields: fields, ctx: ctx}:
+	case al.logChan <- &logJob{level: level, msg: msgCopy, fields: fields, typed: typedCopy, ctx: ctx}:
 		// Successfully sent
 	default:
 		// Channel full, handle error
@@ -129,6 +196,58 @@ func (al *AsyncLogger) Log(level core.Level, msg []byte, fields map[string][]byt
 	}
 }
 
+// LogEntry queues a prebuilt entry for asynchronous writing. The processor must
+// implement EntryLogProcessor. It reports false without taking ownership of the
+// entry when the logger is closed or the channel is full; nothing is reported then,
+// since the caller is expected to write the entry itself.
+func (al *AsyncLogger) LogEntry(entry *core.LogEntry) bool {
+	if al.closed.Load() {
+		return false
+	}
+	if _, ok := al.processor.(EntryLogProcessor); !ok {
+		return false
+	}
+
+	select {
+	case al.logChan <- &logJob{entry: entry}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Flush waits until every job queued before the call has been processed, or until
+// ctx is done. Jobs queued while flushing are not waited for, so Flush returns under
+// steady load. It queues a marker per worker behind the pending jobs; workers pause
+// briefly once they reach it, until all of them have.
+func (al *AsyncLogger) Flush(ctx context.Context) error {
+	if al.closed.Load() {
+		return nil // Close processes everything queued
+	}
+	n := al.workers.Load()
+	if n <= 0 {
+		return nil
+	}
+
+	b := &flushBarrier{reached: make(chan struct{}), abandoned: make(chan struct{})}
+	b.remaining.Store(n)
+	defer close(b.abandoned)
+	for i := int32(0); i < n; i++ {
+		select {
+		case al.logChan <- &logJob{flush: b}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case <-b.reached:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Close closes the async logger
 func (al *AsyncLogger) Close() {
 	if al.closed.CompareAndSwap(false, true) {
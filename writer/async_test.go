@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
@@ -197,6 +198,42 @@ func TestAsyncLoggerBufferFull(t *testing.T) {
 	asyncLogger.Close()
 }
 
+// blockingEntryProcessor is a mockLogProcessor whose WriteEntry blocks until release is closed
+type blockingEntryProcessor struct {
+	mockLogProcessor
+	release chan struct{}
+}
+
+func (p *blockingEntryProcessor) WriteEntry(entry *core.LogEntry) {
+	<-p.release
+}
+
+// TestAsyncLoggerLogEntryFull tests that a rejected entry is left to the caller without a report
+func TestAsyncLoggerLogEntryFull(t *testing.T) {
+	var reported []error
+	processor := &blockingEntryProcessor{
+		mockLogProcessor: mockLogProcessor{
+			errorHandler: func(err error) { reported = append(reported, err) },
+			errOutMu:     &sync.Mutex{},
+		},
+		release: make(chan struct{}),
+	}
+	asyncLogger := NewAsyncLogger(processor, 1, 1, time.Second, false)
+	defer asyncLogger.Close()
+	defer close(processor.release)
+
+	rejected := false
+	for i := 0; i < 10 && !rejected; i++ {
+		rejected = !asyncLogger.LogEntry(&core.LogEntry{Level: core.INFO})
+	}
+	if !rejected {
+		t.Fatal("Expected LogEntry to reject an entry when the channel is full")
+	}
+	if len(reported) != 0 {
+		t.Errorf("Expected no report for an entry the caller writes, got %v", reported)
+	}
+}
+
 // TestAsyncLoggerWithTimeout tests async logger with log processing timeout
 func TestAsyncLoggerWithTimeout(t *testing.T) {
 	processor := &mockLogProcessor{}
@@ -435,4 +472,98 @@ func TestAsyncLoggerClosedBehavior(t *testing.T) {
 	
 	// The message might or might not be processed depending on timing,
 	// but it should not cause a panic
-}
\ No newline at end of file
+}
+// slowLogProcessor delays every log call so queued jobs stay pending
+type slowLogProcessor struct {
+	mockLogProcessor
+	delay time.Duration
+}
+
+func (s *slowLogProcessor) Log(ctx context.Context, level core.Level, msg []byte, fields map[string][]byte) {
+	time.Sleep(s.delay)
+	s.mockLogProcessor.Log(ctx, level, msg, fields)
+}
+
+// TestAsyncLoggerFlush tests waiting for queued jobs without closing the logger
+func TestAsyncLoggerFlush(t *testing.T) {
+	processor := &slowLogProcessor{delay: 5 * time.Millisecond}
+	asyncLogger := NewAsyncLogger(processor, 1, 100, 0, true)
+	defer asyncLogger.Close()
+
+	for i := 0; i < 5; i++ {
+		asyncLogger.Log(core.INFO, []byte("queued"), nil, context.Background())
+	}
+	if err := asyncLogger.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+
+	processor.mu.Lock()
+	calls := processor.logCalls
+	processor.mu.Unlock()
+	if calls != 5 {
+		t.Errorf("Expected 5 processed jobs after Flush, got %d", calls)
+	}
+
+	// The logger keeps working after a flush, and a flush respects its deadline
+	for i := 0; i < 10; i++ {
+		asyncLogger.Log(core.INFO, []byte("slow"), nil, context.Background())
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := asyncLogger.Flush(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+}
+
+// TestAsyncLoggerFlushUnderLoad tests that Flush only waits for the jobs queued before it
+func TestAsyncLoggerFlushUnderLoad(t *testing.T) {
+	processor := &slowLogProcessor{delay: 100 * time.Microsecond}
+	asyncLogger := NewAsyncLogger(processor, 4, 100, 0, true)
+	defer asyncLogger.Close()
+
+	// Producers keep the queue from ever draining
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					asyncLogger.Log(core.INFO, []byte("load"), nil, context.Background())
+					runtime.Gosched()
+				}
+			}
+		}()
+	}
+	defer func() {
+		close(stop)
+		wg.Wait()
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	// Queued directly since Log drops jobs while the queue is full
+	for i := 0; i < 3; i++ {
+		asyncLogger.logChan <- &logJob{level: core.WARN, msg: []byte("before flush"), ctx: context.Background()}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := asyncLogger.Flush(ctx); err != nil {
+		t.Fatalf("Flush returned error under load: %v", err)
+	}
+
+	processor.mu.Lock()
+	defer processor.mu.Unlock()
+	warned := 0
+	for _, job := range processor.loggedEntries {
+		if job.level == core.WARN {
+			warned++
+		}
+	}
+	if warned != 3 {
+		t.Errorf("Expected the 3 jobs queued before Flush to be processed, got %d", warned)
+	}
+}
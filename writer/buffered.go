@@ -1,6 +1,7 @@
 package writer
 
 import (
+	"context"
 	"io"
 	"os"
 	"sync"
@@ -34,6 +35,7 @@ type BufferedWriter struct {
 	bufferSize    int
 	flushInterval time.Duration
 	done          chan struct{}
+	flushRequests chan chan struct{} // Flush requests, the worker closes the channel once flushed
 	wg            sync.WaitGroup
 	mu            sync.Mutex
 	droppedLogs   int64
@@ -54,6 +56,7 @@ func NewBufferedWriter(writer io.Writer, bufferSize int, flushInterval time.Dura
 		bufferSize:    bufferSize,
 		flushInterval: flushInterval,
 		done:          make(chan struct{}),
+		flushRequests: make(chan chan struct{}),
 		lastFlush:     time.Now(),
 		batchSize:     batchSize,
 		batchTimeout:  batchTimeout,
@@ -196,6 +199,21 @@ func (bw *BufferedWriter) flushWorker() {
 				bw.flushBatch(batch)
 				batch = batch[:0]
 			}
+
+		case flushed := <-bw.flushRequests:
+			// Take everything queued before the request
+			for n := len(bw.buffer); n > 0; n-- {
+				batch = append(batch, <-bw.buffer)
+			}
+			bw.flushBatch(batch)
+			batch = batch[:0]
+			if batchTimer != nil && !batchTimer.Stop() {
+				select {
+				case <-batchTimer.C:
+				default:
+				}
+			}
+			close(flushed)
 		}
 	}
 }
@@ -246,6 +264,33 @@ func (bw *BufferedWriter) Stats() map[string]interface{} {
 	}
 }
 
+// Flush writes everything buffered before the call to the underlying writer, waiting
+// until it is written or ctx is done. Unlike Close, the writer stays usable.
+func (bw *BufferedWriter) Flush(ctx context.Context) error {
+	bw.mu.Lock()
+	closed := bw.closed
+	bw.mu.Unlock()
+	if closed {
+		return nil // Close already flushed everything
+	}
+
+	flushed := make(chan struct{})
+	select {
+	case bw.flushRequests <- flushed:
+	case <-bw.done:
+		return nil // Closed concurrently, Close flushes everything
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Close closes the buffered writer, ensuring all logs are flushed.
 func (bw *BufferedWriter) Close() error {
 	// Use a mutex to make sure Close is thread-safe and only done once
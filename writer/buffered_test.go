@@ -2,6 +2,7 @@ package writer
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"strings"
 	"sync"
@@ -468,4 +469,33 @@ func TestBufferedWriterWithBytesBuffer(t *testing.T) {
 	if !strings.Contains(output, "line 0") || !strings.Contains(output, "line 6") {
 		t.Error("Not all lines were written to buffer")
 	}
-}
\ No newline at end of file
+}
+// TestBufferedWriterFlush tests flushing buffered data without closing the writer
+func TestBufferedWriterFlush(t *testing.T) {
+	output := &mockWriteCounter{}
+	// Long interval and large batches so nothing is written unless flushed
+	bufferedWriter := NewBufferedWriter(output, 100, time.Hour, nil, 100, time.Hour)
+	defer bufferedWriter.Close()
+
+	bufferedWriter.Write([]byte("first\n"))
+	bufferedWriter.Write([]byte("second\n"))
+	if err := bufferedWriter.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if got := string(output.GetData()); got != "first\nsecond\n" {
+		t.Errorf("Expected both lines after Flush, got %q", got)
+	}
+
+	bufferedWriter.Write([]byte("third\n"))
+	if err := bufferedWriter.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush returned error: %v", err)
+	}
+	if got := string(output.GetData()); !strings.HasSuffix(got, "third\n") {
+		t.Errorf("Expected the writer to stay usable after Flush, got %q", got)
+	}
+
+	bufferedWriter.Close()
+	if err := bufferedWriter.Flush(context.Background()); err != nil {
+		t.Errorf("Flush after Close should be a no-op, got %v", err)
+	}
+}
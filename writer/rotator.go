@@ -49,6 +49,17 @@ func (w *RotatingFileWriter) Write(p []byte) (n int, err error) {
 	return n, err
 }
 
+// Sync commits the current contents of the file to stable storage.
+func (w *RotatingFileWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	return w.file.Sync()
+}
+
 // Close closes the underlying file.
 func (w *RotatingFileWriter) Close() error {
 	w.mu.Lock()
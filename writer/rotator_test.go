@@ -313,4 +313,23 @@ func TestRotatingFileWriterWithNonExistentDir(t *testing.T) {
 		t.Error("NewRotatingFileWriter should have returned nil for non-existent directory")
 		rotatingWriter.Close()
 	}
-}
\ No newline at end of file
+}
+// TestRotatingFileWriterSync tests syncing the file before and after Close
+func TestRotatingFileWriterSync(t *testing.T) {
+	tempFile := filepath.Join(t.TempDir(), "test_sync.log")
+
+	rotatingWriter, err := NewRotatingFileWriter(tempFile, &config.RotationConfig{})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter returned error: %v", err)
+	}
+
+	_, _ = rotatingWriter.Write([]byte("synced\n"))
+	if err := rotatingWriter.Sync(); err != nil {
+		t.Errorf("Sync returned error: %v", err)
+	}
+
+	rotatingWriter.Close()
+	if err := rotatingWriter.Sync(); err != nil {
+		t.Errorf("Sync after Close returned error: %v", err)
+	}
+}